@@ -0,0 +1,285 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/notify"
+	"github.com/agiacomolli/go-inter/watch"
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultInterval = 5 * time.Minute
+
+// Balance fields checked by the rules.
+const (
+	AvailableField               = "available"
+	LimitField                   = "limit"
+	CheckOnHoldField             = "check_on_hold"
+	JudiciallyBlockedField       = "judicially_blocked"
+	AdministrativelyBlockedField = "administratively_blocked"
+)
+
+var balanceFields = map[string]func(inter.Balance) float32{
+	AvailableField:               func(b inter.Balance) float32 { return b.Available },
+	LimitField:                   func(b inter.Balance) float32 { return b.Limit },
+	CheckOnHoldField:             func(b inter.Balance) float32 { return b.CheckOnHold },
+	JudiciallyBlockedField:       func(b inter.Balance) float32 { return b.JudiciallyBlocked },
+	AdministrativelyBlockedField: func(b inter.Balance) float32 { return b.AdministrativelyBlocked },
+}
+
+// Rule triggers when the balance field goes below or above the threshold,
+// only one of them being set. The alert is resolved once the field crosses
+// the threshold back by more than the hysteresis, so values oscillating
+// around the threshold do not flap.
+type Rule struct {
+	Name       string   `yaml:"name" json:"name"`
+	Field      string   `yaml:"field" json:"field"`
+	Below      *float32 `yaml:"below" json:"below"`
+	Above      *float32 `yaml:"above" json:"above"`
+	Hysteresis float32  `yaml:"hysteresis" json:"hysteresis"`
+}
+
+type Account struct {
+	// Account is the checking account number, the account bound to the
+	// session when empty.
+	Account string `yaml:"account" json:"account"`
+	Rules   []Rule `yaml:"rules" json:"rules"`
+}
+
+type Config struct {
+	Accounts []Account `yaml:"accounts" json:"accounts"`
+}
+
+// Load reads the configuration from a JSON file, if its extension is .json,
+// or from a YAML file otherwise.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&c)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&c)
+	}
+
+	if err != nil {
+		return Config{}, fmt.Errorf("could not parse alerts: %w", err)
+	}
+
+	return c, nil
+}
+
+func (r Rule) validate() error {
+	if _, ok := balanceFields[r.Field]; !ok {
+		return fmt.Errorf("invalid field %q", r.Field)
+	}
+
+	if (r.Below == nil) == (r.Above == nil) {
+		return errors.New("either below or above is required")
+	}
+
+	if r.Hysteresis < 0 {
+		return errors.New("hysteresis must not be negative")
+	}
+
+	return nil
+}
+
+func (r Rule) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+
+	if r.Below != nil {
+		return fmt.Sprintf("%s below %.2f", r.Field, *r.Below)
+	}
+
+	return fmt.Sprintf("%s above %.2f", r.Field, *r.Above)
+}
+
+// evaluate returns whether the rule is triggered, given whether it was
+// triggered before.
+func (r Rule) evaluate(b inter.Balance, triggered bool) bool {
+	v := balanceFields[r.Field](b)
+
+	if r.Below != nil {
+		if triggered {
+			return v < *r.Below+r.Hysteresis
+		}
+
+		return v < *r.Below
+	}
+
+	if triggered {
+		return v > *r.Above-r.Hysteresis
+	}
+
+	return v > *r.Above
+}
+
+type Options struct {
+	Interval time.Duration
+	// MaxBackoff is the maximum delay between polls after errors.
+	MaxBackoff time.Duration
+	// ExpiryMargin before the session expiration to connect again.
+	ExpiryMargin time.Duration
+	// OnError is called with the errors that could not be sent to the
+	// sink.
+	OnError func(err error)
+}
+
+// Monitor polls the accounts balances, sending an alert event when a rule
+// triggers and a resolved event when it stops triggering.
+type Monitor struct {
+	sessions *watch.Sessions
+	sink     notify.Sink
+	config   Config
+	opts     Options
+	now      func() time.Time
+
+	accounts []string
+	// Triggered rules, indexed by account and rule position.
+	triggered map[string][]bool
+}
+
+func New(connect watch.Connector, sink notify.Sink, c Config, opts Options) (*Monitor, error) {
+	if len(c.Accounts) == 0 {
+		return nil, errors.New("no account configured")
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = watch.DefaultMaxBackoff
+	}
+
+	if opts.OnError == nil {
+		opts.OnError = func(error) {}
+	}
+
+	m := &Monitor{
+		sessions:  watch.NewSessions(connect, opts.ExpiryMargin),
+		sink:      sink,
+		config:    c,
+		opts:      opts,
+		now:       time.Now,
+		accounts:  make([]string, 0, len(c.Accounts)),
+		triggered: make(map[string][]bool, len(c.Accounts)),
+	}
+
+	for _, a := range c.Accounts {
+		if _, ok := m.triggered[a.Account]; ok {
+			return nil, fmt.Errorf("account %q configured more than once", a.Account)
+		}
+
+		for i, r := range a.Rules {
+			if err := r.validate(); err != nil {
+				return nil, fmt.Errorf("account %q rule %d: %w", a.Account, i+1, err)
+			}
+		}
+
+		m.accounts = append(m.accounts, a.Account)
+		m.triggered[a.Account] = make([]bool, len(a.Rules))
+	}
+
+	return m, nil
+}
+
+// Run polls until the context is done, as the watch.Run function.
+func (m *Monitor) Run(ctx context.Context) error {
+	return watch.Run(ctx, m.opts.Interval, m.opts.MaxBackoff, m.Poll)
+}
+
+// Poll checks the balance of every account once, as the
+// watch.Sessions.ForEach method.
+func (m *Monitor) Poll(ctx context.Context) error {
+	now := m.now()
+
+	return m.sessions.ForEach(ctx, now, m.accounts, m.sink, m.opts.OnError,
+		func(ctx context.Context, account string, f watch.Fetcher) error {
+			b, err := f.Balance(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			m.check(ctx, now, account, b)
+
+			return nil
+		})
+}
+
+func (m *Monitor) check(ctx context.Context, now time.Time, account string, b inter.Balance) {
+	var rules []Rule
+
+	for _, a := range m.config.Accounts {
+		if a.Account == account {
+			rules = a.Rules
+		}
+	}
+
+	triggered := m.triggered[account]
+
+	for i, r := range rules {
+		t := r.evaluate(b, triggered[i])
+		if t == triggered[i] {
+			continue
+		}
+
+		triggered[i] = t
+
+		e := newEvent(now, account, r, b, t)
+
+		if err := m.sink.Notify(ctx, e); err != nil {
+			m.opts.OnError(fmt.Errorf("could not notify %s: %w", e.Message, err))
+		}
+	}
+}
+
+func newEvent(now time.Time, account string, r Rule, b inter.Balance, triggered bool) notify.Event {
+	condition, threshold := "below", r.Below
+	if r.Above != nil {
+		condition, threshold = "above", r.Above
+	}
+
+	value := balanceFields[r.Field](b)
+
+	e := notify.Event{
+		Type:    notify.AlertEvent,
+		Account: account,
+		Time:    now,
+		Alert: &notify.Alert{
+			Rule:      r.name(),
+			Field:     r.Field,
+			Condition: condition,
+			Threshold: fmt.Sprintf("%.2f", *threshold),
+			Value:     fmt.Sprintf("%.2f", value),
+		},
+		Message: fmt.Sprintf("%s is %.2f, %s %.2f", r.Field, value, condition, *threshold),
+	}
+
+	if !triggered {
+		e.Type = notify.ResolvedEvent
+		e.Message = fmt.Sprintf("%s is %.2f, no longer %s %.2f", r.Field, value, condition, *threshold)
+	}
+
+	return e
+}
@@ -0,0 +1,181 @@
+package alert
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/notify"
+	"github.com/agiacomolli/go-inter/watch"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = `
+accounts:
+  - account: "12345"
+    rules:
+      - name: low balance
+        field: available
+        below: 1000
+        hysteresis: 200
+      - field: judicially_blocked
+        above: 0
+`
+
+var testNow = time.Date(2022, time.February, 2, 10, 0, 0, 0, time.UTC)
+
+type fakeFetcher struct {
+	balance inter.Balance
+}
+
+func (f *fakeFetcher) Transactions(ctx context.Context, start, end time.Time) ([]inter.Transaction, error) {
+	return nil, nil
+}
+
+func (f *fakeFetcher) Balance(ctx context.Context, date time.Time) (inter.Balance, error) {
+	return f.balance, nil
+}
+
+type recorder struct {
+	events []notify.Event
+}
+
+func (r *recorder) Notify(ctx context.Context, e notify.Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func writeConfig(t *testing.T, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+
+	return path
+}
+
+func newTestMonitor(t *testing.T, f *fakeFetcher, r *recorder) *Monitor {
+	t.Helper()
+
+	c, err := Load(writeConfig(t, "alerts.yaml", testConfig))
+	require.NoError(t, err)
+
+	m, err := New(func(ctx context.Context) (watch.Session, error) {
+		return watch.Session{
+			Fetcher: func(account string) watch.Fetcher { return f },
+		}, nil
+	}, r, c, Options{})
+	require.NoError(t, err)
+
+	m.now = func() time.Time { return testNow }
+
+	return m
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("loads YAML", func(t *testing.T) {
+		c, err := Load(writeConfig(t, "alerts.yaml", testConfig))
+		require.NoError(t, err)
+		require.Len(t, c.Accounts, 1)
+		require.Equal(t, "12345", c.Accounts[0].Account)
+		require.Len(t, c.Accounts[0].Rules, 2)
+		require.Equal(t, float32(1000), *c.Accounts[0].Rules[0].Below)
+		require.Equal(t, float32(200), c.Accounts[0].Rules[0].Hysteresis)
+	})
+
+	t.Run("loads JSON", func(t *testing.T) {
+		c, err := Load(writeConfig(t, "alerts.json",
+			`{"accounts": [{"rules": [{"field": "available", "below": 10}]}]}`))
+		require.NoError(t, err)
+		require.Len(t, c.Accounts[0].Rules, 1)
+	})
+
+	t.Run("returns an error on unknown fields", func(t *testing.T) {
+		_, err := Load(writeConfig(t, "alerts.yaml", "accounts:\n  - acount: \"1\"\n"))
+		require.Error(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	below := float32(10)
+
+	for _, c := range []Config{
+		{},
+		{Accounts: []Account{{Rules: []Rule{{Field: "balance", Below: &below}}}}},
+		{Accounts: []Account{{Rules: []Rule{{Field: AvailableField}}}}},
+		{Accounts: []Account{{Rules: []Rule{{Field: AvailableField, Below: &below, Above: &below}}}}},
+		{Accounts: []Account{{Rules: []Rule{{Field: AvailableField, Below: &below, Hysteresis: -1}}}}},
+		{Accounts: []Account{{Account: "1"}, {Account: "1"}}},
+	} {
+		_, err := New(nil, &recorder{}, c, Options{})
+		require.Error(t, err)
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("alerts and resolves with hysteresis", func(t *testing.T) {
+		f := &fakeFetcher{balance: inter.Balance{Available: 1500}}
+		r := &recorder{}
+		m := newTestMonitor(t, f, r)
+
+		require.NoError(t, m.Poll(ctx))
+		require.Empty(t, r.events)
+
+		f.balance.Available = 999.99
+		require.NoError(t, m.Poll(ctx))
+		require.Len(t, r.events, 1)
+		require.Equal(t, notify.Event{
+			Type:    notify.AlertEvent,
+			Account: "12345",
+			Time:    testNow,
+			Alert: &notify.Alert{
+				Rule:      "low balance",
+				Field:     AvailableField,
+				Condition: "below",
+				Threshold: "1000.00",
+				Value:     "999.99",
+			},
+			Message: "available is 999.99, below 1000.00",
+		}, r.events[0])
+
+		// Still triggered inside the hysteresis band.
+		for _, v := range []float32{1100, 900, 1199.99} {
+			f.balance.Available = v
+			require.NoError(t, m.Poll(ctx))
+		}
+		require.Len(t, r.events, 1)
+
+		f.balance.Available = 1200
+		require.NoError(t, m.Poll(ctx))
+		require.Len(t, r.events, 2)
+		require.Equal(t, notify.ResolvedEvent, r.events[1].Type)
+		require.Equal(t, "available is 1200.00, no longer below 1000.00", r.events[1].Message)
+
+		// Not triggered again until below the threshold.
+		f.balance.Available = 1000
+		require.NoError(t, m.Poll(ctx))
+		require.Len(t, r.events, 2)
+	})
+
+	t.Run("alerts blocked amounts", func(t *testing.T) {
+		f := &fakeFetcher{balance: inter.Balance{Available: 5000, JudiciallyBlocked: 120}}
+		r := &recorder{}
+		m := newTestMonitor(t, f, r)
+
+		require.NoError(t, m.Poll(ctx))
+		require.Len(t, r.events, 1)
+		require.Equal(t, notify.AlertEvent, r.events[0].Type)
+		require.Equal(t, "judicially_blocked above 0.00", r.events[0].Alert.Rule)
+		require.Equal(t, "120.00", r.events[0].Alert.Value)
+
+		f.balance.JudiciallyBlocked = 0
+		require.NoError(t, m.Poll(ctx))
+		require.Len(t, r.events, 2)
+		require.Equal(t, notify.ResolvedEvent, r.events[1].Type)
+	})
+}
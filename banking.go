@@ -2,8 +2,9 @@ package inter
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -14,8 +15,10 @@ import (
 )
 
 type Banking struct {
-	client *Client
-	token  Token
+	client      *Client
+	token       Token
+	account     string
+	checkScopes bool
 }
 
 func NewBanking(client *Client, token Token) *Banking {
@@ -25,6 +28,38 @@ func NewBanking(client *Client, token Token) *Banking {
 	}
 }
 
+// WithAccount returns a copy of the service bound to the given checking
+// account, which is required when the application has access to more than
+// one account.
+func (b *Banking) WithAccount(account string) *Banking {
+	tmp := *b
+	tmp.account = account
+
+	return &tmp
+}
+
+// WithScopeCheck returns a copy of the service that fails with a ScopeError,
+// before calling the API, when the token lacks the scopes required by a
+// method.
+func (b *Banking) WithScopeCheck() *Banking {
+	tmp := *b
+	tmp.checkScopes = true
+
+	return &tmp
+}
+
+func (b *Banking) requireScopes(method string) error {
+	if !b.checkScopes {
+		return nil
+	}
+
+	return checkScopes(b.token, method)
+}
+
+func (b *Banking) Account() string {
+	return b.account
+}
+
 type Balance struct {
 	Available               float32
 	Limit                   float32
@@ -33,7 +68,19 @@ type Balance struct {
 	AdministrativelyBlocked float32
 }
 
+// Booked returns the balance booked in the account, which adds the amounts
+// on hold and blocked to the available balance, without the overdraft
+// limit.
+func (b Balance) Booked() float32 {
+	return FromCents(Cents(b.Available) + Cents(b.CheckOnHold) +
+		Cents(b.JudiciallyBlocked) + Cents(b.AdministrativelyBlocked))
+}
+
 func (b *Banking) Balance(ctx context.Context, date time.Time) (Balance, error) {
+	if err := b.requireScopes("Banking.Balance"); err != nil {
+		return Balance{}, err
+	}
+
 	endpoint := fmt.Sprintf("%s/banking/v2/saldo", b.client.apiBaseUrl)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
@@ -43,6 +90,9 @@ func (b *Banking) Balance(ctx context.Context, date time.Time) (Balance, error)
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", b.token.Data))
+	if b.account != "" {
+		req.Header.Add("x-conta-corrente", b.account)
+	}
 
 	q := url.Values{}
 	q.Add("dataSaldo", date.Format(time.DateOnly))
@@ -61,7 +111,7 @@ func (b *Banking) Balance(ctx context.Context, date time.Time) (Balance, error)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return Balance{}, errors.New(string(data))
+		return Balance{}, newApiError(resp, data)
 	}
 
 	return parseApiBalance(data)
@@ -141,6 +191,10 @@ type Transaction struct {
 }
 
 func (b *Banking) Transactions(ctx context.Context, start, end time.Time) ([]Transaction, error) {
+	if err := b.requireScopes("Banking.Transactions"); err != nil {
+		return []Transaction{}, err
+	}
+
 	endpoint := fmt.Sprintf("%s/banking/v2/extrato", b.client.apiBaseUrl)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
@@ -150,6 +204,9 @@ func (b *Banking) Transactions(ctx context.Context, start, end time.Time) ([]Tra
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", b.token.Data))
+	if b.account != "" {
+		req.Header.Add("x-conta-corrente", b.account)
+	}
 
 	q := url.Values{}
 	q.Add("dataInicio", start.Format(time.DateOnly))
@@ -169,7 +226,7 @@ func (b *Banking) Transactions(ctx context.Context, start, end time.Time) ([]Tra
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return []Transaction{}, errors.New(string(data))
+		return []Transaction{}, newApiError(resp, data)
 	}
 
 	return parseApiTransactions(data)
@@ -241,3 +298,34 @@ func parseApiTransactions(d []byte) ([]Transaction, error) {
 
 	return transactions, nil
 }
+
+// Fingerprint identifies a transaction by its content, as the statement
+// endpoint does not return transaction identifiers.
+func (t Transaction) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%.2f|%s|%s", t.Date.Format(time.DateOnly),
+		t.Type, t.Operation, t.Value, t.Title, t.Description)
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// TransactionIDs returns an identifier for each transaction based on its
+// fingerprint, adding a sequence suffix to repeated transactions so that
+// identical entries in the same statement still get distinct identifiers.
+func TransactionIDs(transactions []Transaction) []string {
+	ids := make([]string, 0, len(transactions))
+	seen := make(map[string]int, len(transactions))
+
+	for _, v := range transactions {
+		id := v.Fingerprint()
+
+		seen[id]++
+		if n := seen[id]; n > 1 {
+			id = fmt.Sprintf("%s-%d", id, n)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
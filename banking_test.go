@@ -181,3 +181,68 @@ func TestBankingTransactions(t *testing.T) {
 		require.Equal(t, got, want)
 	})
 }
+
+func TestBalanceBooked(t *testing.T) {
+	b := Balance{
+		Available:               1432.57,
+		Limit:                   1000,
+		CheckOnHold:             100.1,
+		JudiciallyBlocked:       0.2,
+		AdministrativelyBlocked: 50,
+	}
+
+	require.Equal(t, float32(1582.87), b.Booked())
+}
+
+func TestTransactionIDs(t *testing.T) {
+	t.Run("returns distinct identifiers for repeated transactions", func(t *testing.T) {
+		transaction := Transaction{
+			Date:      time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC),
+			Type:      PixTransactionType,
+			Operation: DebitTransactionOperation,
+			Value:     10,
+			Title:     "Pix enviado",
+		}
+
+		other := transaction
+		other.Value = 20
+
+		ids := TransactionIDs([]Transaction{transaction, other, transaction})
+		require.Len(t, ids, 3)
+		require.Equal(t, ids[0], transaction.Fingerprint())
+		require.Equal(t, ids[1], other.Fingerprint())
+		require.Equal(t, ids[2], transaction.Fingerprint()+"-2")
+	})
+
+	t.Run("returns stable identifiers", func(t *testing.T) {
+		transaction := Transaction{Title: "title"}
+		require.Equal(t, transaction.Fingerprint(), transaction.Fingerprint())
+		require.Len(t, transaction.Fingerprint(), 32)
+	})
+}
+
+func TestBankingWithAccount(t *testing.T) {
+	t.Run("sends the account header", func(t *testing.T) {
+		var account string
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			account = r.Header.Get("x-conta-corrente")
+			fmt.Fprintln(w, `{"disponivel": 1}`)
+		}))
+		defer ts.Close()
+
+		client := NewClient(tls.Certificate{})
+		client.apiBaseUrl = ts.URL
+
+		banking := NewBanking(client, Token{})
+
+		_, err := banking.Balance(context.Background(), time.Now())
+		require.NoError(t, err)
+		require.Empty(t, account)
+
+		_, err = banking.WithAccount("12345").Balance(context.Background(), time.Now())
+		require.NoError(t, err)
+		require.Equal(t, account, "12345")
+		require.Empty(t, banking.Account())
+	})
+}
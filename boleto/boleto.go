@@ -0,0 +1,360 @@
+package boleto
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidLength     = errors.New("invalid boleto length")
+	ErrInvalidCharacter  = errors.New("invalid boleto character")
+	ErrInvalidCheckDigit = errors.New("invalid boleto check digit")
+	ErrInvalidBoleto     = errors.New("invalid boleto")
+)
+
+const (
+	barcodeLength             = 44
+	bankDigitableLength       = 47
+	collectionDigitableLength = 48
+)
+
+type Kind int
+
+const (
+	BankKind = Kind(iota + 1)
+	CollectionKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case BankKind:
+		return "bank"
+	case CollectionKind:
+		return "collection"
+	}
+
+	return "invalid"
+}
+
+// Boleto holds the information encoded in a boleto barcode. Bank boletos
+// (títulos bancários) carry the bank code, the due date factor and the
+// amount, while collection boletos (arrecadação) carry the segment and the
+// amount or reference value.
+type Boleto struct {
+	Barcode string
+	Kind    Kind
+
+	BankCode  string
+	Currency  int
+	DueFactor int
+
+	Segment         int
+	EffectiveAmount bool
+
+	// Amount in cents.
+	Amount int64
+}
+
+// Parse accepts either a barcode or a linha digitável, ignoring any non
+// digit separators like spaces and dots, and validates its check digits.
+func Parse(s string) (Boleto, error) {
+	digits, err := digitsOnly(s)
+	if err != nil {
+		return Boleto{}, err
+	}
+
+	switch len(digits) {
+	case barcodeLength:
+		return ParseBarcode(digits)
+	case bankDigitableLength, collectionDigitableLength:
+		return ParseDigitableLine(digits)
+	}
+
+	return Boleto{}, ErrInvalidLength
+}
+
+func ParseBarcode(s string) (Boleto, error) {
+	digits, err := digitsOnly(s)
+	if err != nil {
+		return Boleto{}, err
+	}
+
+	if len(digits) != barcodeLength {
+		return Boleto{}, ErrInvalidLength
+	}
+
+	if digits[0] == '8' {
+		return parseCollectionBarcode(digits)
+	}
+
+	return parseBankBarcode(digits)
+}
+
+func ParseDigitableLine(s string) (Boleto, error) {
+	digits, err := digitsOnly(s)
+	if err != nil {
+		return Boleto{}, err
+	}
+
+	switch len(digits) {
+	case bankDigitableLength:
+		return parseBankDigitableLine(digits)
+	case collectionDigitableLength:
+		return parseCollectionDigitableLine(digits)
+	}
+
+	return Boleto{}, ErrInvalidLength
+}
+
+// DigitableLine returns the linha digitável for the boleto, without any
+// formatting separators.
+func (b Boleto) DigitableLine() string {
+	switch b.Kind {
+	case BankKind:
+		return bankDigitableLine(b.Barcode)
+	case CollectionKind:
+		return collectionDigitableLine(b.Barcode)
+	}
+
+	return ""
+}
+
+// FormattedDigitableLine returns the linha digitável using the separators
+// printed on boletos.
+func (b Boleto) FormattedDigitableLine() string {
+	l := b.DigitableLine()
+
+	switch b.Kind {
+	case BankKind:
+		return l[0:5] + "." + l[5:10] + " " + l[10:15] + "." + l[15:21] + " " +
+			l[21:26] + "." + l[26:32] + " " + l[32:33] + " " + l[33:47]
+	case CollectionKind:
+		return l[0:11] + "-" + l[11:12] + " " + l[12:23] + "-" + l[23:24] + " " +
+			l[24:35] + "-" + l[35:36] + " " + l[36:47] + "-" + l[47:48]
+	}
+
+	return ""
+}
+
+// DueDate returns the due date of a bank boleto, resolving the factor
+// rollover relative to the current date. It returns false when the boleto
+// has no due date.
+func (b Boleto) DueDate() (time.Time, bool) {
+	return b.DueDateAt(time.Now())
+}
+
+// DueDateAt returns the due date of a bank boleto, resolving the factor
+// rollover relative to the given reference date.
+func (b Boleto) DueDateAt(ref time.Time) (time.Time, bool) {
+	if b.Kind != BankKind || b.DueFactor == 0 {
+		return time.Time{}, false
+	}
+
+	return dueDateFromFactor(b.DueFactor, ref), true
+}
+
+func parseBankBarcode(barcode string) (Boleto, error) {
+	if bankBarcodeCheckDigit(barcode) != barcode[4] {
+		return Boleto{}, ErrInvalidCheckDigit
+	}
+
+	currency, _ := strconv.Atoi(barcode[3:4])
+	factor, _ := strconv.Atoi(barcode[5:9])
+	amount, _ := strconv.ParseInt(barcode[9:19], 10, 64)
+
+	return Boleto{
+		Barcode:         barcode,
+		Kind:            BankKind,
+		BankCode:        barcode[0:3],
+		Currency:        currency,
+		DueFactor:       factor,
+		EffectiveAmount: true,
+		Amount:          amount,
+	}, nil
+}
+
+func parseBankDigitableLine(line string) (Boleto, error) {
+	fields := []string{line[0:10], line[10:21], line[21:32]}
+
+	for _, f := range fields {
+		n := len(f) - 1
+		if mod10(f[:n]) != f[n] {
+			return Boleto{}, ErrInvalidCheckDigit
+		}
+	}
+
+	barcode := line[0:4] + line[32:33] + line[33:47] + line[4:9] +
+		line[10:20] + line[21:31]
+
+	return parseBankBarcode(barcode)
+}
+
+func bankDigitableLine(barcode string) string {
+	f1 := barcode[0:4] + barcode[19:24]
+	f2 := barcode[24:34]
+	f3 := barcode[34:44]
+
+	var b strings.Builder
+	b.WriteString(f1)
+	b.WriteByte(mod10(f1))
+	b.WriteString(f2)
+	b.WriteByte(mod10(f2))
+	b.WriteString(f3)
+	b.WriteByte(mod10(f3))
+	b.WriteString(barcode[4:5])
+	b.WriteString(barcode[5:19])
+
+	return b.String()
+}
+
+func bankBarcodeCheckDigit(barcode string) byte {
+	r := weightedSum(barcode[0:4]+barcode[5:44]) % 11
+
+	dv := 11 - r
+	if dv == 0 || dv == 10 || dv == 11 {
+		dv = 1
+	}
+
+	return byte('0' + dv)
+}
+
+func parseCollectionBarcode(barcode string) (Boleto, error) {
+	checkDigit, ok := collectionCheckDigitFunc(barcode[2])
+	if !ok {
+		return Boleto{}, ErrInvalidBoleto
+	}
+
+	if checkDigit(barcode[0:3]+barcode[4:44]) != barcode[3] {
+		return Boleto{}, ErrInvalidCheckDigit
+	}
+
+	segment, _ := strconv.Atoi(barcode[1:2])
+	if segment == 0 {
+		return Boleto{}, ErrInvalidBoleto
+	}
+
+	amount, _ := strconv.ParseInt(barcode[4:15], 10, 64)
+
+	return Boleto{
+		Barcode:         barcode,
+		Kind:            CollectionKind,
+		Segment:         segment,
+		EffectiveAmount: barcode[2] == '6' || barcode[2] == '8',
+		Amount:          amount,
+	}, nil
+}
+
+func parseCollectionDigitableLine(line string) (Boleto, error) {
+	if line[0] != '8' {
+		return Boleto{}, ErrInvalidBoleto
+	}
+
+	checkDigit, ok := collectionCheckDigitFunc(line[2])
+	if !ok {
+		return Boleto{}, ErrInvalidBoleto
+	}
+
+	var barcode strings.Builder
+
+	for i := 0; i < 4; i++ {
+		block := line[i*12 : i*12+11]
+		if checkDigit(block) != line[i*12+11] {
+			return Boleto{}, ErrInvalidCheckDigit
+		}
+
+		barcode.WriteString(block)
+	}
+
+	return parseCollectionBarcode(barcode.String())
+}
+
+func collectionDigitableLine(barcode string) string {
+	checkDigit, _ := collectionCheckDigitFunc(barcode[2])
+
+	var b strings.Builder
+
+	for i := 0; i < 4; i++ {
+		block := barcode[i*11 : i*11+11]
+		b.WriteString(block)
+		b.WriteByte(checkDigit(block))
+	}
+
+	return b.String()
+}
+
+// The third barcode digit tells which algorithm computes the check digits of
+// collection boletos: 6 and 7 use modulo 10, 8 and 9 use modulo 11.
+func collectionCheckDigitFunc(id byte) (func(string) byte, bool) {
+	switch id {
+	case '6', '7':
+		return mod10, true
+	case '8', '9':
+		return collectionMod11, true
+	}
+
+	return nil, false
+}
+
+func collectionMod11(s string) byte {
+	r := weightedSum(s) % 11
+	if r == 0 || r == 1 {
+		return '0'
+	}
+
+	return byte('0' + 11 - r)
+}
+
+// Weights 2 to 9 are applied from right to left.
+func weightedSum(s string) int {
+	sum := 0
+	weight := 2
+
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * weight
+
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	return sum
+}
+
+// Weights 2 and 1 are alternated from right to left, adding the digits of
+// each product.
+func mod10(s string) byte {
+	sum := 0
+	weight := 2
+
+	for i := len(s) - 1; i >= 0; i-- {
+		p := int(s[i]-'0') * weight
+		sum += p/10 + p%10
+
+		if weight == 2 {
+			weight = 1
+		} else {
+			weight = 2
+		}
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+func digitsOnly(s string) (string, error) {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '.' || r == '-' || r == '\t' || r == '\n':
+		default:
+			return "", ErrInvalidCharacter
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,146 @@
+package boleto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBankBarcode             = "00193373700000001000500940144816060680935031"
+	testBankDigitableLine       = "00190500954014481606906809350314337370000000100"
+	testCollectionBarcode       = "85890000001234500670000000000000000000000001"
+	testCollectionDigitableLine = "858900000018234500670002000000000000000000000019"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("returns an error if length is invalid", func(t *testing.T) {
+		_, err := Parse("0019050095401448160690680935031433737000000010")
+		require.ErrorIs(t, err, ErrInvalidLength)
+	})
+
+	t.Run("returns an error if there are invalid characters", func(t *testing.T) {
+		_, err := Parse("0019050095401448160690680935031433737000000010a")
+		require.ErrorIs(t, err, ErrInvalidCharacter)
+	})
+
+	t.Run("parses a bank barcode", func(t *testing.T) {
+		want := Boleto{
+			Barcode:         testBankBarcode,
+			Kind:            BankKind,
+			BankCode:        "001",
+			Currency:        9,
+			DueFactor:       3737,
+			EffectiveAmount: true,
+			Amount:          100,
+		}
+
+		got, err := Parse(testBankBarcode)
+		require.NoError(t, err)
+		require.Equal(t, got, want)
+	})
+
+	t.Run("parses a formatted bank digitable line", func(t *testing.T) {
+		got, err := Parse("00190.50095 40144.816069 06809.350314 3 37370000000100")
+		require.NoError(t, err)
+		require.Equal(t, got.Barcode, testBankBarcode)
+	})
+
+	t.Run("parses a collection barcode", func(t *testing.T) {
+		want := Boleto{
+			Barcode:         testCollectionBarcode,
+			Kind:            CollectionKind,
+			Segment:         5,
+			EffectiveAmount: true,
+			Amount:          12345,
+		}
+
+		got, err := Parse(testCollectionBarcode)
+		require.NoError(t, err)
+		require.Equal(t, got, want)
+	})
+
+	t.Run("parses a collection digitable line", func(t *testing.T) {
+		got, err := Parse(testCollectionDigitableLine)
+		require.NoError(t, err)
+		require.Equal(t, got.Barcode, testCollectionBarcode)
+	})
+
+	t.Run("returns an error on bank barcode typos", func(t *testing.T) {
+		_, err := Parse("00193373700000001000500940144816060680935032")
+		require.ErrorIs(t, err, ErrInvalidCheckDigit)
+	})
+
+	t.Run("returns an error on bank digitable line typos", func(t *testing.T) {
+		_, err := Parse("00190500954014481606906809350314337370000000101")
+		require.ErrorIs(t, err, ErrInvalidCheckDigit)
+
+		_, err = Parse("00190500964014481606906809350314337370000000100")
+		require.ErrorIs(t, err, ErrInvalidCheckDigit)
+	})
+
+	t.Run("returns an error on collection digitable line typos", func(t *testing.T) {
+		_, err := Parse("858900000018234500670002000000000000000000000018")
+		require.ErrorIs(t, err, ErrInvalidCheckDigit)
+	})
+}
+
+func TestDigitableLine(t *testing.T) {
+	t.Run("converts bank barcodes", func(t *testing.T) {
+		b, err := ParseBarcode(testBankBarcode)
+		require.NoError(t, err)
+		require.Equal(t, b.DigitableLine(), testBankDigitableLine)
+		require.Equal(t, b.FormattedDigitableLine(),
+			"00190.50095 40144.816069 06809.350314 3 37370000000100")
+	})
+
+	t.Run("converts collection barcodes", func(t *testing.T) {
+		b, err := ParseBarcode(testCollectionBarcode)
+		require.NoError(t, err)
+		require.Equal(t, b.DigitableLine(), testCollectionDigitableLine)
+		require.Equal(t, b.FormattedDigitableLine(),
+			"85890000001-8 23450067000-2 00000000000-0 00000000001-9")
+	})
+}
+
+func TestDueDate(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("returns false without due date", func(t *testing.T) {
+		_, ok := Boleto{Kind: BankKind}.DueDate()
+		require.False(t, ok)
+
+		_, ok = Boleto{Kind: CollectionKind, DueFactor: 1000}.DueDate()
+		require.False(t, ok)
+	})
+
+	t.Run("uses the first factor cycle", func(t *testing.T) {
+		b := Boleto{Kind: BankKind, DueFactor: 9999}
+
+		got, ok := b.DueDateAt(date(2025, time.February, 1))
+		require.True(t, ok)
+		require.Equal(t, got, date(2025, time.February, 21))
+	})
+
+	t.Run("handles the 2025 factor rollover", func(t *testing.T) {
+		b := Boleto{Kind: BankKind, DueFactor: 1000}
+
+		got, ok := b.DueDateAt(date(2025, time.February, 1))
+		require.True(t, ok)
+		require.Equal(t, got, date(2025, time.February, 22))
+
+		b.DueFactor = 1001
+		got, ok = b.DueDateAt(date(2025, time.March, 1))
+		require.True(t, ok)
+		require.Equal(t, got, date(2025, time.February, 23))
+	})
+
+	t.Run("computes factors from dates", func(t *testing.T) {
+		require.Equal(t, DueFactor(date(2000, time.July, 3)), 1000)
+		require.Equal(t, DueFactor(date(2025, time.February, 21)), 9999)
+		require.Equal(t, DueFactor(date(2025, time.February, 22)), 1000)
+	})
+}
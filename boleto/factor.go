@@ -0,0 +1,50 @@
+package boleto
+
+import (
+	"time"
+)
+
+// The due date factor counts days since 1997-10-07 and goes from 1000 to
+// 9999. After reaching 9999 on 2025-02-21 it restarted at 1000, so every
+// factor maps to dates 9000 days apart.
+var factorBaseDate = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+
+const factorCycleDays = 9000
+
+func dueDateFromFactor(factor int, ref time.Time) time.Time {
+	ref = time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC)
+
+	date := factorBaseDate.AddDate(0, 0, factor)
+
+	for {
+		next := date.AddDate(0, 0, factorCycleDays)
+		if absDays(next.Sub(ref)) >= absDays(date.Sub(ref)) {
+			break
+		}
+
+		date = next
+	}
+
+	return date
+}
+
+// DueFactor returns the factor encoded in bank boletos for the given due
+// date.
+func DueFactor(date time.Time) int {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := int(date.Sub(factorBaseDate).Hours() / 24)
+	if days < 1000 {
+		return days
+	}
+
+	return (days-1000)%factorCycleDays + 1000
+}
+
+func absDays(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
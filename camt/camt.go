@@ -0,0 +1,229 @@
+package camt
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+const (
+	Namespace       = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+	DefaultCurrency = "BRL"
+)
+
+// ErrAccountRequired is returned for statements without the account
+// identification, which camt.053 requires.
+var ErrAccountRequired = errors.New("account identification is required")
+
+// Statement is a statement period, where the opening balance is the one at
+// the end of the day before Start and the closing balance is the one at the
+// end of End.
+type Statement struct {
+	ID        string
+	AccountID string
+	Currency  string
+	CreatedAt time.Time
+
+	Start time.Time
+	End   time.Time
+
+	OpeningBalance inter.Balance
+	ClosingBalance inter.Balance
+
+	Transactions []inter.Transaction
+}
+
+// Write renders the statement as a camt.053.001.02 document. Empty
+// identification, currency and creation time get default values, while the
+// account identification is required.
+func Write(w io.Writer, s Statement) error {
+	if s.AccountID == "" {
+		return ErrAccountRequired
+	}
+
+	if s.Currency == "" {
+		s.Currency = DefaultCurrency
+	}
+
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now()
+	}
+
+	if s.ID == "" {
+		s.ID = fmt.Sprintf("%s-%s", s.Start.Format("20060102"), s.End.Format("20060102"))
+	}
+
+	doc := document{
+		Xmlns: Namespace,
+		Statement: bankToCustomerStatement{
+			GroupHeader: groupHeader{
+				MessageID:  s.ID,
+				CreatedAt:  formatDateTime(s.CreatedAt),
+				Pagination: &pagination{Page: "1", LastPage: true},
+			},
+			Statement: statement{
+				ID:        s.ID,
+				CreatedAt: formatDateTime(s.CreatedAt),
+				Period: period{
+					From: formatDateTime(startOfDay(s.Start)),
+					To:   formatDateTime(startOfDay(s.End).Add(24*time.Hour - time.Second)),
+				},
+				Account: account{
+					ID:       accountID{Other: otherID{ID: s.AccountID}},
+					Currency: s.Currency,
+				},
+				Balances: []balance{
+					newBalance("OPBD", s.OpeningBalance.Available, s.Currency, s.Start),
+					newBalance("CLBD", s.ClosingBalance.Available, s.Currency, s.End),
+				},
+				Summary: newSummary(s.Transactions),
+			},
+		},
+	}
+
+	for i, id := range inter.TransactionIDs(s.Transactions) {
+		doc.Statement.Statement.Entries = append(doc.Statement.Statement.Entries,
+			newEntry(s.Transactions[i], id, s.Currency))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func newBalance(code string, value float32, currency string, date time.Time) balance {
+	return balance{
+		Type:      balanceType{CodeOrProprietary: codeOrProprietary{Code: code}},
+		Amount:    newAmount(abs(value), currency),
+		Indicator: indicator(value < 0),
+		Date:      dateChoice{Date: date.Format(time.DateOnly)},
+	}
+}
+
+func newSummary(transactions []inter.Transaction) *summary {
+	var credits, debits numberAndSum
+
+	for _, v := range transactions {
+		if v.Operation == inter.DebitTransactionOperation {
+			debits.add(v.Value)
+		} else {
+			credits.add(v.Value)
+		}
+	}
+
+	net := credits.sum - debits.sum
+
+	return &summary{
+		Total: totalEntries{
+			Count:     fmt.Sprint(credits.count + debits.count),
+			Sum:       formatAmount(credits.sum + debits.sum),
+			NetAmount: formatAmount(abs64(net)),
+			Indicator: indicator(net < 0),
+		},
+		Credits: credits.entries(),
+		Debits:  debits.entries(),
+	}
+}
+
+func newEntry(t inter.Transaction, id, currency string) entry {
+	date := dateChoice{Date: t.Date.Format(time.DateOnly)}
+
+	e := entry{
+		Reference:       id,
+		Amount:          newAmount(t.Value, currency),
+		Indicator:       indicator(t.Operation == inter.DebitTransactionOperation),
+		Status:          "BOOK",
+		BookingDate:     date,
+		ValueDate:       date,
+		ServicerRef:     id,
+		BankTransaction: bankTransactionCode{Proprietary: proprietary{Code: bankTransactionCodeFor(t)}},
+		AdditionalInfo:  truncate(t.Title, 500),
+	}
+
+	if t.Description != "" {
+		e.RemittanceDetail = &entryDetails{
+			Transaction: transactionDetails{
+				RemittanceInfo: remittanceInfo{Unstructured: truncate(t.Description, 140)},
+			},
+		}
+	}
+
+	return e
+}
+
+func bankTransactionCodeFor(t inter.Transaction) string {
+	switch t.Type {
+	case inter.PixTransactionType:
+		return "PIX"
+	case inter.PagamentoTransactionType:
+		return "PAGAMENTO"
+	case inter.TransferenciaTransactionType:
+		return "TRANSFERENCIA"
+	}
+
+	return "OUTROS"
+}
+
+func indicator(debit bool) string {
+	if debit {
+		return "DBIT"
+	}
+
+	return "CRDT"
+}
+
+func newAmount(v float32, currency string) amount {
+	return amount{Currency: currency, Value: formatAmount(float64(v))}
+}
+
+func formatAmount(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+func formatDateTime(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05")
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+func abs64(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n])
+}
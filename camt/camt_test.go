@@ -0,0 +1,113 @@
+package camt
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	date := time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)
+
+	s := Statement{
+		AccountID: "12345",
+		CreatedAt: date.AddDate(0, 0, 11),
+		Start:     date,
+		End:       date.AddDate(0, 0, 10),
+		OpeningBalance: inter.Balance{
+			Available: -50,
+		},
+		ClosingBalance: inter.Balance{
+			Available: 1030.5,
+		},
+		Transactions: []inter.Transaction{
+			{
+				Date:        date,
+				Type:        inter.TransferenciaTransactionType,
+				Operation:   inter.CreditTransactionOperation,
+				Value:       1100.5,
+				Title:       "Transferência recebida",
+				Description: "TED RECEBIDA - 001 BANCO 001 S.A.",
+			},
+			{
+				Date:      date.AddDate(0, 0, 3),
+				Type:      inter.PixTransactionType,
+				Operation: inter.DebitTransactionOperation,
+				Value:     20,
+				Title:     "Pix enviado",
+			},
+		},
+	}
+
+	var b strings.Builder
+
+	err := Write(&b, s)
+	require.NoError(t, err)
+
+	out := b.String()
+	require.True(t, strings.HasPrefix(out, xml.Header))
+	require.Contains(t, out, `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">`)
+	require.Contains(t, out, "<FrDtTm>2022-02-02T00:00:00</FrDtTm>")
+	require.Contains(t, out, "<ToDtTm>2022-02-12T23:59:59</ToDtTm>")
+
+	var doc struct {
+		Statement struct {
+			ID       string `xml:"Id"`
+			Account  string `xml:"Acct>Id>Othr>Id"`
+			Balances []struct {
+				Code      string `xml:"Tp>CdOrPrtry>Cd"`
+				Amount    amount `xml:"Amt"`
+				Indicator string `xml:"CdtDbtInd"`
+				Date      string `xml:"Dt>Dt"`
+			} `xml:"Bal"`
+			Net     string `xml:"TxsSummry>TtlNtries>TtlNetNtryAmt"`
+			Entries []struct {
+				Amount      string `xml:"Amt"`
+				Indicator   string `xml:"CdtDbtInd"`
+				Status      string `xml:"Sts"`
+				BookingDate string `xml:"BookgDt>Dt"`
+				Code        string `xml:"BkTxCd>Prtry>Cd"`
+				Remittance  string `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+			} `xml:"Ntry"`
+		} `xml:"BkToCstmrStmt>Stmt"`
+	}
+
+	err = xml.Unmarshal([]byte(out), &doc)
+	require.NoError(t, err)
+
+	require.Equal(t, doc.Statement.ID, "20220202-20220212")
+	require.Equal(t, doc.Statement.Account, "12345")
+
+	require.Len(t, doc.Statement.Balances, 2)
+	require.Equal(t, doc.Statement.Balances[0].Code, "OPBD")
+	require.Equal(t, doc.Statement.Balances[0].Amount.Value, "50.00")
+	require.Equal(t, doc.Statement.Balances[0].Amount.Currency, "BRL")
+	require.Equal(t, doc.Statement.Balances[0].Indicator, "DBIT")
+	require.Equal(t, doc.Statement.Balances[1].Code, "CLBD")
+	require.Equal(t, doc.Statement.Balances[1].Amount.Value, "1030.50")
+	require.Equal(t, doc.Statement.Balances[1].Indicator, "CRDT")
+	require.Equal(t, doc.Statement.Balances[1].Date, "2022-02-12")
+	require.Equal(t, doc.Statement.Net, "1080.50")
+
+	require.Len(t, doc.Statement.Entries, 2)
+	require.Equal(t, doc.Statement.Entries[0].Amount, "1100.50")
+	require.Equal(t, doc.Statement.Entries[0].Indicator, "CRDT")
+	require.Equal(t, doc.Statement.Entries[0].Status, "BOOK")
+	require.Equal(t, doc.Statement.Entries[0].BookingDate, "2022-02-02")
+	require.Equal(t, doc.Statement.Entries[0].Code, "TRANSFERENCIA")
+	require.Equal(t, doc.Statement.Entries[0].Remittance, "TED RECEBIDA - 001 BANCO 001 S.A.")
+	require.Equal(t, doc.Statement.Entries[1].Indicator, "DBIT")
+	require.Empty(t, doc.Statement.Entries[1].Remittance)
+}
+
+func TestWriteWithoutAccount(t *testing.T) {
+	var b strings.Builder
+
+	err := Write(&b, Statement{Start: time.Now(), End: time.Now()})
+	require.ErrorIs(t, err, ErrAccountRequired)
+	require.Empty(t, b.String())
+}
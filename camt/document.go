@@ -0,0 +1,151 @@
+package camt
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Elements must follow the sequence defined in the camt.053.001.02 schema.
+
+type document struct {
+	XMLName   xml.Name                `xml:"Document"`
+	Xmlns     string                  `xml:"xmlns,attr"`
+	Statement bankToCustomerStatement `xml:"BkToCstmrStmt"`
+}
+
+type bankToCustomerStatement struct {
+	GroupHeader groupHeader `xml:"GrpHdr"`
+	Statement   statement   `xml:"Stmt"`
+}
+
+type groupHeader struct {
+	MessageID  string      `xml:"MsgId"`
+	CreatedAt  string      `xml:"CreDtTm"`
+	Pagination *pagination `xml:"MsgPgntn,omitempty"`
+}
+
+type pagination struct {
+	Page     string `xml:"PgNb"`
+	LastPage bool   `xml:"LastPgInd"`
+}
+
+type statement struct {
+	ID        string    `xml:"Id"`
+	CreatedAt string    `xml:"CreDtTm"`
+	Period    period    `xml:"FrToDt"`
+	Account   account   `xml:"Acct"`
+	Balances  []balance `xml:"Bal"`
+	Summary   *summary  `xml:"TxsSummry,omitempty"`
+	Entries   []entry   `xml:"Ntry"`
+}
+
+type period struct {
+	From string `xml:"FrDtTm"`
+	To   string `xml:"ToDtTm"`
+}
+
+type account struct {
+	ID       accountID `xml:"Id"`
+	Currency string    `xml:"Ccy"`
+}
+
+type accountID struct {
+	Other otherID `xml:"Othr"`
+}
+
+type otherID struct {
+	ID string `xml:"Id"`
+}
+
+type balance struct {
+	Type      balanceType `xml:"Tp"`
+	Amount    amount      `xml:"Amt"`
+	Indicator string      `xml:"CdtDbtInd"`
+	Date      dateChoice  `xml:"Dt"`
+}
+
+type balanceType struct {
+	CodeOrProprietary codeOrProprietary `xml:"CdOrPrtry"`
+}
+
+type codeOrProprietary struct {
+	Code string `xml:"Cd"`
+}
+
+type amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type dateChoice struct {
+	Date string `xml:"Dt"`
+}
+
+type summary struct {
+	Total   totalEntries  `xml:"TtlNtries"`
+	Credits *numberOfSums `xml:"TtlCdtNtries,omitempty"`
+	Debits  *numberOfSums `xml:"TtlDbtNtries,omitempty"`
+}
+
+type totalEntries struct {
+	Count     string `xml:"NbOfNtries"`
+	Sum       string `xml:"Sum"`
+	NetAmount string `xml:"TtlNetNtryAmt"`
+	Indicator string `xml:"CdtDbtInd"`
+}
+
+type numberOfSums struct {
+	Count string `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type numberAndSum struct {
+	count int
+	sum   float64
+}
+
+func (n *numberAndSum) add(v float32) {
+	n.count++
+	n.sum += float64(v)
+}
+
+func (n numberAndSum) entries() *numberOfSums {
+	if n.count == 0 {
+		return nil
+	}
+
+	return &numberOfSums{Count: fmt.Sprint(n.count), Sum: formatAmount(n.sum)}
+}
+
+type entry struct {
+	Reference        string              `xml:"NtryRef"`
+	Amount           amount              `xml:"Amt"`
+	Indicator        string              `xml:"CdtDbtInd"`
+	Status           string              `xml:"Sts"`
+	BookingDate      dateChoice          `xml:"BookgDt"`
+	ValueDate        dateChoice          `xml:"ValDt"`
+	ServicerRef      string              `xml:"AcctSvcrRef"`
+	BankTransaction  bankTransactionCode `xml:"BkTxCd"`
+	RemittanceDetail *entryDetails       `xml:"NtryDtls,omitempty"`
+	AdditionalInfo   string              `xml:"AddtlNtryInf,omitempty"`
+}
+
+type bankTransactionCode struct {
+	Proprietary proprietary `xml:"Prtry"`
+}
+
+type proprietary struct {
+	Code string `xml:"Cd"`
+}
+
+type entryDetails struct {
+	Transaction transactionDetails `xml:"TxDtls"`
+}
+
+type transactionDetails struct {
+	RemittanceInfo remittanceInfo `xml:"RmtInf"`
+}
+
+type remittanceInfo struct {
+	Unstructured string `xml:"Ustrd"`
+}
@@ -0,0 +1,257 @@
+package category
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/agiacomolli/go-inter"
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultCategory = "uncategorized"
+
+// Rule matches transactions on every condition set, unset conditions
+// matching any transaction. Rules with higher priority are evaluated first
+// and rules with the same priority are evaluated in order.
+type Rule struct {
+	Category string `yaml:"category" json:"category"`
+	Priority int    `yaml:"priority" json:"priority"`
+
+	Types     []string `yaml:"types" json:"types"`
+	Operation string   `yaml:"operation" json:"operation"`
+
+	Min *float32 `yaml:"min" json:"min"`
+	Max *float32 `yaml:"max" json:"max"`
+
+	// Regular expressions matching the title, the description or any of
+	// them.
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description" json:"description"`
+	Match       string `yaml:"match" json:"match"`
+
+	// CPF or CNPJ of the counterpart, found in the transaction description.
+	Document string `yaml:"document" json:"document"`
+}
+
+type Config struct {
+	Default string `yaml:"default" json:"default"`
+	Rules   []Rule `yaml:"rules" json:"rules"`
+}
+
+type Engine struct {
+	defaultCategory string
+	rules           []compiledRule
+}
+
+type compiledRule struct {
+	category string
+	priority int
+	filter   inter.TransactionFilter
+}
+
+type Categorized struct {
+	inter.Transaction
+	Category string
+}
+
+// Load reads the rules from a JSON file, if its extension is .json, or from
+// a YAML file otherwise.
+func Load(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&c)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&c)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rules: %w", err)
+	}
+
+	return New(c)
+}
+
+func New(c Config) (*Engine, error) {
+	e := &Engine{
+		defaultCategory: c.Default,
+		rules:           make([]compiledRule, 0, len(c.Rules)),
+	}
+
+	if e.defaultCategory == "" {
+		e.defaultCategory = DefaultCategory
+	}
+
+	for i, r := range c.Rules {
+		compiled, err := compileRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+
+		e.rules = append(e.rules, compiled)
+	}
+
+	sort.SliceStable(e.rules, func(i, j int) bool {
+		return e.rules[i].priority > e.rules[j].priority
+	})
+
+	return e, nil
+}
+
+func compileRule(r Rule) (compiledRule, error) {
+	if r.Category == "" {
+		return compiledRule{}, errors.New("category is required")
+	}
+
+	var filters []inter.TransactionFilter
+
+	if len(r.Types) > 0 {
+		types := make([]inter.TransactionType, 0, len(r.Types))
+
+		for _, v := range r.Types {
+			t, err := inter.ParseTransactionType(v)
+			if err != nil {
+				return compiledRule{}, err
+			}
+
+			types = append(types, t)
+		}
+
+		filters = append(filters, inter.TypeFilter(types...))
+	}
+
+	if r.Operation != "" {
+		o, err := inter.ParseTransactionOperation(r.Operation)
+		if err != nil {
+			return compiledRule{}, err
+		}
+
+		filters = append(filters, inter.OperationFilter(o))
+	}
+
+	if r.Min != nil {
+		filters = append(filters, inter.MinValueFilter(*r.Min))
+	}
+
+	if r.Max != nil {
+		filters = append(filters, inter.MaxValueFilter(*r.Max))
+	}
+
+	for _, m := range []struct {
+		expr  string
+		field func(inter.Transaction) string
+	}{
+		{r.Title, func(t inter.Transaction) string { return t.Title }},
+		{r.Description, func(t inter.Transaction) string { return t.Description }},
+	} {
+		if m.expr == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(m.expr)
+		if err != nil {
+			return compiledRule{}, err
+		}
+
+		field := m.field
+		filters = append(filters, func(t inter.Transaction) bool {
+			return re.MatchString(field(t))
+		})
+	}
+
+	if r.Match != "" {
+		re, err := regexp.Compile(r.Match)
+		if err != nil {
+			return compiledRule{}, err
+		}
+
+		filters = append(filters, inter.MatchFilter(re))
+	}
+
+	if r.Document != "" {
+		doc, err := inter.ParseDocument(r.Document)
+		if err != nil {
+			return compiledRule{}, err
+		}
+
+		filters = append(filters, DocumentFilter(doc))
+	}
+
+	return compiledRule{
+		category: r.Category,
+		priority: r.Priority,
+		filter:   inter.MatchAll(filters...),
+	}, nil
+}
+
+// Categorize returns the category of the first matching rule, or the
+// default category.
+func (e *Engine) Categorize(t inter.Transaction) string {
+	for _, r := range e.rules {
+		if r.filter(t) {
+			return r.category
+		}
+	}
+
+	return e.defaultCategory
+}
+
+func (e *Engine) Annotate(transactions []inter.Transaction) []Categorized {
+	categorized := make([]Categorized, 0, len(transactions))
+
+	for _, v := range transactions {
+		categorized = append(categorized, Categorized{
+			Transaction: v,
+			Category:    e.Categorize(v),
+		})
+	}
+
+	return categorized
+}
+
+var documentRegexp = regexp.MustCompile(`\b(?:[0-9A-Z]{2}\.?[0-9A-Z]{3}\.?[0-9A-Z]{3}/?[0-9A-Z]{4}-?[0-9]{2}|[0-9]{3}\.?[0-9]{3}\.?[0-9]{3}-?[0-9]{2})\b`)
+
+// Documents returns the valid CPFs and CNPJs found in the transaction
+// description.
+func Documents(t inter.Transaction) []inter.Document {
+	var docs []inter.Document
+
+	for _, m := range documentRegexp.FindAllString(strings.ToUpper(t.Description), -1) {
+		doc, err := inter.ParseDocument(m)
+		if err != nil {
+			continue
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs
+}
+
+func DocumentFilter(doc inter.Document) inter.TransactionFilter {
+	return func(t inter.Transaction) bool {
+		for _, v := range Documents(t) {
+			if v.String() == doc.String() {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,160 @@
+package category
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+var testRules = `
+default: other
+rules:
+  - category: taxes
+    types: [pagamento]
+    match: "(?i)darf|das "
+  - category: payroll
+    operation: debit
+    types: [pix, transferencia]
+    min: 1000
+    description: "(?i)salario"
+  - category: fees
+    priority: 10
+    operation: debit
+    max: 10
+  - category: customers
+    operation: credit
+    document: "11.222.333/0001-81"
+`
+
+func writeRules(t *testing.T, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("returns an error if file does not exist", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "rules.yaml"))
+		require.Error(t, err)
+	})
+
+	t.Run("returns an error on unknown fields", func(t *testing.T) {
+		_, err := Load(writeRules(t, "rules.yaml", "rules:\n  - category: a\n    amount: 1\n"))
+		require.Error(t, err)
+	})
+
+	t.Run("returns an error on invalid rules", func(t *testing.T) {
+		for _, data := range []string{
+			"rules:\n  - types: [pix]\n",
+			"rules:\n  - category: a\n    types: [wix]\n",
+			"rules:\n  - category: a\n    operation: x\n",
+			"rules:\n  - category: a\n    match: \"(\"\n",
+			"rules:\n  - category: a\n    document: \"123\"\n",
+		} {
+			_, err := Load(writeRules(t, "rules.yaml", data))
+			require.Error(t, err, data)
+		}
+	})
+
+	t.Run("loads json rules", func(t *testing.T) {
+		e, err := Load(writeRules(t, "rules.json",
+			`{"rules": [{"category": "pix", "types": ["pix"]}]}`))
+		require.NoError(t, err)
+		require.Equal(t, e.Categorize(inter.Transaction{Type: inter.PixTransactionType}), "pix")
+		require.Equal(t, e.Categorize(inter.Transaction{}), DefaultCategory)
+	})
+}
+
+func TestCategorize(t *testing.T) {
+	e, err := Load(writeRules(t, "rules.yml", testRules))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		transaction inter.Transaction
+		want        string
+	}{
+		{
+			name: "taxes",
+			transaction: inter.Transaction{
+				Type:        inter.PagamentoTransactionType,
+				Operation:   inter.DebitTransactionOperation,
+				Value:       500,
+				Description: "PAGAMENTO DARF",
+			},
+			want: "taxes",
+		},
+		{
+			name: "payroll",
+			transaction: inter.Transaction{
+				Type:        inter.PixTransactionType,
+				Operation:   inter.DebitTransactionOperation,
+				Value:       3000,
+				Description: "PIX ENVIADO - SALARIO",
+			},
+			want: "payroll",
+		},
+		{
+			name: "fees before other rules",
+			transaction: inter.Transaction{
+				Type:        inter.PagamentoTransactionType,
+				Operation:   inter.DebitTransactionOperation,
+				Value:       5,
+				Description: "TARIFA DARF",
+			},
+			want: "fees",
+		},
+		{
+			name: "customers by document",
+			transaction: inter.Transaction{
+				Type:        inter.PixTransactionType,
+				Operation:   inter.CreditTransactionOperation,
+				Value:       100,
+				Description: "PIX RECEBIDO - 11.222.333/0001-81 EMPRESA",
+			},
+			want: "customers",
+		},
+		{
+			name: "default",
+			transaction: inter.Transaction{
+				Type:        inter.PixTransactionType,
+				Operation:   inter.CreditTransactionOperation,
+				Value:       100,
+				Description: "PIX RECEBIDO - 11222333000182",
+			},
+			want: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("categorizes "+tt.name, func(t *testing.T) {
+			require.Equal(t, e.Categorize(tt.transaction), tt.want)
+		})
+	}
+
+	t.Run("annotates transactions", func(t *testing.T) {
+		got := e.Annotate([]inter.Transaction{tests[0].transaction, tests[4].transaction})
+		require.Equal(t, got, []Categorized{
+			{Transaction: tests[0].transaction, Category: "taxes"},
+			{Transaction: tests[4].transaction, Category: "other"},
+		})
+	})
+}
+
+func TestDocuments(t *testing.T) {
+	t.Run("returns valid documents only", func(t *testing.T) {
+		docs := Documents(inter.Transaction{
+			Description: "TED 529.982.247-25 11222333000181 12345678901",
+		})
+		require.Equal(t, docs, []inter.Document{
+			inter.CPF("52998224725"),
+			inter.CNPJ("11222333000181"),
+		})
+	})
+}
@@ -0,0 +1,259 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var (
+	ErrNoCertificate = errors.New("no certificate found")
+	ErrNoPrivateKey  = errors.New("no private key found")
+	ErrKeyMismatch   = errors.New("private key does not match the certificate")
+)
+
+// Load reads the certificate and its private key from files. PKCS#12 files,
+// with the .p12 or .pfx extensions, are decrypted with the password and
+// hold both. A PEM certificate file holding the private key is read as a
+// bundle, ignoring the key file.
+func Load(certFile, keyFile, password string) (tls.Certificate, error) {
+	switch strings.ToLower(filepath.Ext(certFile)) {
+	case ".p12", ".pfx":
+		return LoadPKCS12(certFile, password)
+	}
+
+	cert, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	if hasPrivateKey(cert) {
+		return ParsePEM(cert)
+	}
+
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return ParsePEM(cert, key)
+}
+
+// LoadPKCS12 reads a PKCS#12 file, such as a .pfx exported by browsers and
+// Windows tools.
+func LoadPKCS12(path, password string) (tls.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	c, err := ParsePKCS12(data, password)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// ParsePKCS12 decrypts the private key, the certificate and the
+// intermediate certificates of PKCS#12 data.
+func ParsePKCS12(data []byte, password string) (tls.Certificate, error) {
+	key, leaf, chain, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not decode PKCS#12: %w", err)
+	}
+
+	certs := [][]byte{leaf.Raw}
+	for _, c := range chain {
+		certs = append(certs, c.Raw)
+	}
+
+	return keyPair(certs, key)
+}
+
+// ParsePEM returns the certificate and the private key found in the PEM
+// data, given as separate certificate and key files or as a single bundle.
+// Certificates are kept in order, the first being the client certificate.
+func ParsePEM(data ...[]byte) (tls.Certificate, error) {
+	var (
+		certs [][]byte
+		key   crypto.PrivateKey
+	)
+
+	for _, rest := range data {
+		for {
+			var block *pem.Block
+
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+
+			switch {
+			case block.Type == "CERTIFICATE":
+				certs = append(certs, block.Bytes)
+			case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+				if key != nil {
+					return tls.Certificate{}, errors.New("more than one private key found")
+				}
+
+				var err error
+
+				key, err = parsePrivateKey(block)
+				if err != nil {
+					return tls.Certificate{}, err
+				}
+			}
+		}
+	}
+
+	return keyPair(certs, key)
+}
+
+// LoadFS reads the PEM certificate and key files from the file system, such
+// as a mounted secret or an embedded directory. The key name may be empty
+// when the certificate file is a bundle.
+func LoadFS(fsys fs.FS, certName, keyName string) (tls.Certificate, error) {
+	cert, err := fs.ReadFile(fsys, certName)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	if keyName == "" {
+		return ParsePEM(cert)
+	}
+
+	key, err := fs.ReadFile(fsys, keyName)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return ParsePEM(cert, key)
+}
+
+// FromEnv reads the base64 encoded PEM certificate and key from the
+// environment variables. The key variable may be empty when the certificate
+// variable holds a bundle. Values that are already PEM are used as is.
+func FromEnv(certVar, keyVar string) (tls.Certificate, error) {
+	cert, err := envPEM(certVar)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	if keyVar == "" {
+		return ParsePEM(cert)
+	}
+
+	key, err := envPEM(keyVar)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return ParsePEM(cert, key)
+}
+
+func envPEM(name string) ([]byte, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	if strings.HasPrefix(v, "-----BEGIN") {
+		return []byte(v), nil
+	}
+
+	// Line breaks are common when encoding with base64 tools.
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(v), ""))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %s: %w", name, err)
+	}
+
+	return data, nil
+}
+
+func hasPrivateKey(data []byte) bool {
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			return false
+		}
+
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			return true
+		}
+	}
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	if x509.IsEncryptedPEMBlock(block) || block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, errors.New("encrypted private keys are not supported, decrypt the key first")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("could not parse %s", strings.ToLower(block.Type))
+}
+
+// keyPair checks that the key matches the first certificate, which is kept
+// parsed as the leaf.
+func keyPair(certs [][]byte, key crypto.PrivateKey) (tls.Certificate, error) {
+	if len(certs) == 0 {
+		return tls.Certificate{}, ErrNoCertificate
+	}
+
+	if key == nil {
+		return tls.Certificate{}, ErrNoPrivateKey
+	}
+
+	leaf, err := x509.ParseCertificate(certs[0])
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not parse certificate: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return tls.Certificate{}, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(leaf.PublicKey) {
+		return tls.Certificate{}, fmt.Errorf("%w %q", ErrKeyMismatch, leaf.Subject.CommonName)
+	}
+
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+	default:
+		return tls.Certificate{}, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return tls.Certificate{
+		Certificate: certs,
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
@@ -0,0 +1,178 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+type testPair struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestPair(t *testing.T, name string) testPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return testPair{key: key, cert: cert}
+}
+
+func (p testPair) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.cert.Raw})
+}
+
+func (p testPair) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(p.key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestParsePEM(t *testing.T) {
+	p := newTestPair(t, "client")
+
+	c, err := ParsePEM(p.certPEM(), p.keyPEM(t))
+	require.NoError(t, err)
+	require.Equal(t, "client", c.Leaf.Subject.CommonName)
+	require.Equal(t, p.key, c.PrivateKey)
+
+	bundle := append(p.keyPEM(t), p.certPEM()...)
+
+	c, err = ParsePEM(bundle)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{p.cert.Raw}, c.Certificate)
+}
+
+func TestParsePEMErrors(t *testing.T) {
+	p := newTestPair(t, "client")
+	other := newTestPair(t, "other")
+
+	_, err := ParsePEM(p.certPEM(), other.keyPEM(t))
+	require.ErrorIs(t, err, ErrKeyMismatch)
+	require.ErrorContains(t, err, `"client"`)
+
+	_, err = ParsePEM(p.certPEM())
+	require.ErrorIs(t, err, ErrNoPrivateKey)
+
+	_, err = ParsePEM(p.keyPEM(t))
+	require.ErrorIs(t, err, ErrNoCertificate)
+
+	_, err = ParsePEM(p.certPEM(), p.keyPEM(t), other.keyPEM(t))
+	require.ErrorContains(t, err, "more than one private key")
+}
+
+func TestParsePKCS12(t *testing.T) {
+	p := newTestPair(t, "client")
+	ca := newTestPair(t, "ca")
+
+	data, err := pkcs12.Modern.Encode(p.key, p.cert, []*x509.Certificate{ca.cert}, "secret")
+	require.NoError(t, err)
+
+	c, err := ParsePKCS12(data, "secret")
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{p.cert.Raw, ca.cert.Raw}, c.Certificate)
+
+	_, err = ParsePKCS12(data, "wrong")
+	require.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	p := newTestPair(t, "client")
+	dir := t.TempDir()
+
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		return path
+	}
+
+	certFile := write("cert.crt", p.certPEM())
+	keyFile := write("cert.key", p.keyPEM(t))
+	bundleFile := write("bundle.pem", append(p.certPEM(), p.keyPEM(t)...))
+
+	data, err := pkcs12.Modern.Encode(p.key, p.cert, nil, "secret")
+	require.NoError(t, err)
+
+	pfxFile := write("cert.pfx", data)
+
+	for _, v := range []struct {
+		cert, key string
+	}{
+		{certFile, keyFile},
+		{bundleFile, filepath.Join(dir, "missing.key")},
+		{pfxFile, ""},
+	} {
+		c, err := Load(v.cert, v.key, "secret")
+		require.NoError(t, err, v.cert)
+		require.Equal(t, p.cert.Raw, c.Certificate[0])
+	}
+
+	_, err = Load(certFile, filepath.Join(dir, "missing.key"), "")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoadFS(t *testing.T) {
+	p := newTestPair(t, "client")
+
+	fsys := fstest.MapFS{
+		"tls.crt":    {Data: p.certPEM()},
+		"tls.key":    {Data: p.keyPEM(t)},
+		"bundle.pem": {Data: append(p.certPEM(), p.keyPEM(t)...)},
+	}
+
+	_, err := LoadFS(fsys, "tls.crt", "tls.key")
+	require.NoError(t, err)
+
+	_, err = LoadFS(fsys, "bundle.pem", "")
+	require.NoError(t, err)
+}
+
+func TestFromEnv(t *testing.T) {
+	p := newTestPair(t, "client")
+
+	t.Setenv("TEST_CERT", base64.StdEncoding.EncodeToString(p.certPEM()))
+	t.Setenv("TEST_KEY", string(p.keyPEM(t)))
+
+	_, err := FromEnv("TEST_CERT", "TEST_KEY")
+	require.NoError(t, err)
+
+	t.Setenv("TEST_BUNDLE", base64.StdEncoding.EncodeToString(append(p.certPEM(), p.keyPEM(t)...)))
+
+	_, err = FromEnv("TEST_BUNDLE", "")
+	require.NoError(t, err)
+
+	_, err = FromEnv("TEST_MISSING", "")
+	require.ErrorContains(t, err, "TEST_MISSING is not set")
+
+	t.Setenv("TEST_INVALID", "not base64!")
+
+	_, err = FromEnv("TEST_INVALID", "")
+	require.ErrorContains(t, err, "could not decode TEST_INVALID")
+}
@@ -0,0 +1,220 @@
+package certificate
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultReloadInterval = time.Minute
+	DefaultExpiryWarning  = 30 * 24 * time.Hour
+
+	// Expiring certificates are reported again after the interval, besides
+	// every reload.
+	expiryWarningInterval = 24 * time.Hour
+)
+
+type ReloaderOptions struct {
+	// Files are polled for changes, triggering a reload when their size or
+	// modification time change. Missing files are not an error, so they
+	// may be replaced by removing and creating them again.
+	Files []string
+
+	// Interval between polls, DefaultReloadInterval when zero.
+	Interval time.Duration
+
+	// ExpiryWarning is how long before expiring OnExpiring is called,
+	// DefaultExpiryWarning when zero.
+	ExpiryWarning time.Duration
+
+	// OnReload is called with each new certificate after the first.
+	OnReload func(leaf *x509.Certificate)
+
+	// OnExpiring is called when the certificate expires within
+	// ExpiryWarning, on every reload and then once a day.
+	OnExpiring func(leaf *x509.Certificate, remaining time.Duration)
+
+	// OnError is called when a reload fails, keeping the current
+	// certificate.
+	OnError func(err error)
+}
+
+// Reloader keeps the client certificate returned by load, reloading it
+// when its files change, so certificates may be rotated without restarting.
+type Reloader struct {
+	load func() (tls.Certificate, error)
+	opts ReloaderOptions
+	now  func() time.Time
+
+	mu          sync.RWMutex
+	cert        *tls.Certificate
+	stats       []fileStat
+	lastWarning time.Time
+}
+
+type fileStat struct {
+	size    int64
+	modTime time.Time
+}
+
+// NewReloader loads the certificate, failing if it can not be loaded.
+func NewReloader(load func() (tls.Certificate, error), opts ReloaderOptions) (*Reloader, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultReloadInterval
+	}
+
+	if opts.ExpiryWarning <= 0 {
+		opts.ExpiryWarning = DefaultExpiryWarning
+	}
+
+	r := &Reloader{load: load, opts: opts, now: time.Now}
+
+	r.stats = r.stat()
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetClientCertificate returns the current certificate, to be set as the
+// tls.Config GetClientCertificate function.
+func (r *Reloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.Certificate(), nil
+}
+
+// Certificate returns the current certificate.
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert
+}
+
+// NotAfter returns the expiration time of the current certificate.
+func (r *Reloader) NotAfter() time.Time {
+	return r.Certificate().Leaf.NotAfter
+}
+
+// Reload loads the certificate, replacing the current one on success.
+func (r *Reloader) Reload() error {
+	c, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	if c.Leaf == nil {
+		if len(c.Certificate) == 0 {
+			return ErrNoCertificate
+		}
+
+		if c.Leaf, err = x509.ParseCertificate(c.Certificate[0]); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	reloaded := r.cert != nil
+	r.cert = &c
+	r.lastWarning = time.Time{}
+	r.mu.Unlock()
+
+	if reloaded && r.opts.OnReload != nil {
+		r.opts.OnReload(c.Leaf)
+	}
+
+	r.checkExpiry()
+
+	return nil
+}
+
+// Run polls the files until the context is done, reloading the certificate
+// when they change.
+func (r *Reloader) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		r.Poll()
+	}
+}
+
+// Poll reloads the certificate if its files changed since the last poll,
+// and reports it if expiring.
+func (r *Reloader) Poll() {
+	stats := r.stat()
+
+	changed := false
+	for i := range stats {
+		if stats[i] != r.stats[i] {
+			changed = true
+		}
+	}
+
+	if !changed {
+		r.checkExpiry()
+		return
+	}
+
+	// A failed reload is retried on the next poll, as the files may be
+	// partially written.
+	if err := r.Reload(); err != nil {
+		if r.opts.OnError != nil {
+			r.opts.OnError(err)
+		}
+
+		r.checkExpiry()
+
+		return
+	}
+
+	r.stats = stats
+}
+
+func (r *Reloader) stat() []fileStat {
+	stats := make([]fileStat, len(r.opts.Files))
+
+	for i, name := range r.opts.Files {
+		// Files that can not be read are compared as missing.
+		fi, err := os.Stat(name)
+		if err != nil {
+			continue
+		}
+
+		stats[i] = fileStat{size: fi.Size(), modTime: fi.ModTime()}
+	}
+
+	return stats
+}
+
+func (r *Reloader) checkExpiry() {
+	if r.opts.OnExpiring == nil {
+		return
+	}
+
+	now := r.now()
+
+	r.mu.Lock()
+	leaf := r.cert.Leaf
+	remaining := leaf.NotAfter.Sub(now)
+	warn := remaining < r.opts.ExpiryWarning &&
+		(r.lastWarning.IsZero() || now.Sub(r.lastWarning) >= expiryWarningInterval)
+	if warn {
+		r.lastWarning = now
+	}
+	r.mu.Unlock()
+
+	if warn {
+		r.opts.OnExpiring(leaf, remaining)
+	}
+}
@@ -0,0 +1,105 @@
+package certificate
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.crt")
+	keyFile := filepath.Join(dir, "cert.key")
+
+	mtime := time.Now().Add(-time.Hour)
+
+	write := func(p testPair) {
+		require.NoError(t, os.WriteFile(certFile, p.certPEM(), 0600))
+		require.NoError(t, os.WriteFile(keyFile, p.keyPEM(t), 0600))
+
+		// File systems may not change the modification time of files
+		// written within the same tick.
+		mtime = mtime.Add(time.Second)
+		require.NoError(t, os.Chtimes(certFile, mtime, mtime))
+		require.NoError(t, os.Chtimes(keyFile, mtime, mtime))
+	}
+
+	first := newTestPair(t, "first")
+	write(first)
+
+	var (
+		reloaded []string
+		errs     []error
+	)
+
+	r, err := NewReloader(func() (tls.Certificate, error) {
+		return Load(certFile, keyFile, "")
+	}, ReloaderOptions{
+		Files: []string{certFile, keyFile},
+		OnReload: func(leaf *x509.Certificate) {
+			reloaded = append(reloaded, leaf.Subject.CommonName)
+		},
+		OnError: func(err error) {
+			errs = append(errs, err)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, first.cert.NotAfter, r.NotAfter())
+
+	r.Poll()
+	require.Empty(t, reloaded)
+
+	second := newTestPair(t, "second")
+	write(second)
+
+	r.Poll()
+	require.Equal(t, []string{"second"}, reloaded)
+
+	c, err := r.GetClientCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, second.cert.Raw, c.Certificate[0])
+
+	// A key not matching the certificate keeps the current one, retrying
+	// on every poll.
+	require.NoError(t, os.WriteFile(keyFile, first.keyPEM(t), 0600))
+	require.NoError(t, os.Chtimes(keyFile, mtime.Add(time.Minute), mtime.Add(time.Minute)))
+
+	r.Poll()
+	r.Poll()
+	require.Len(t, errs, 2)
+	require.ErrorIs(t, errs[0], ErrKeyMismatch)
+	require.Equal(t, second.cert.Raw, r.Certificate().Certificate[0])
+}
+
+func TestReloaderExpiry(t *testing.T) {
+	p := newTestPair(t, "client")
+
+	var warnings []time.Duration
+
+	r, err := NewReloader(func() (tls.Certificate, error) {
+		return ParsePEM(p.certPEM(), p.keyPEM(t))
+	}, ReloaderOptions{
+		OnExpiring: func(leaf *x509.Certificate, remaining time.Duration) {
+			warnings = append(warnings, remaining)
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	r.Poll()
+	require.Len(t, warnings, 1)
+
+	now = now.Add(expiryWarningInterval)
+
+	r.Poll()
+	require.Len(t, warnings, 2)
+	require.Less(t, warnings[1], time.Duration(0))
+}
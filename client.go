@@ -26,3 +26,19 @@ func NewClient(c tls.Certificate) *Client {
 		apiBaseUrl: defaultApiBaseUri,
 	}
 }
+
+// NewClientFunc returns a client that asks for the client certificate on
+// each new connection, such as certificate.Reloader.GetClientCertificate,
+// so the certificate may change without creating a new client.
+func NewClientFunc(get func(*tls.CertificateRequestInfo) (*tls.Certificate, error)) *Client {
+	tr := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			GetClientCertificate: get,
+		},
+	}
+
+	return &Client{
+		Client:     &http.Client{Transport: tr},
+		apiBaseUrl: defaultApiBaseUri,
+	}
+}
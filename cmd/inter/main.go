@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/agiacomolli/go-inter/internal/authcmd"
+	"github.com/agiacomolli/go-inter/internal/bankingcmd"
+	"github.com/agiacomolli/go-inter/internal/cli"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	root := &cli.Command{
+		Name:  "inter",
+		Short: "command line client of the Inter APIs",
+		Commands: []*cli.Command{
+			authcmd.Command("auth"),
+			bankingcmd.Command("banking"),
+		},
+	}
+	root.Commands = append(root.Commands, cli.CompletionCommand(root))
+
+	os.Exit(cli.Execute(ctx, root, os.Args[1:]))
+}
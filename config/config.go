@@ -0,0 +1,186 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// PathEnv sets the configuration file used instead of the default one.
+	PathEnv = "INTER_CONFIG"
+
+	// ProfileEnv sets the profile used when none is given.
+	ProfileEnv = "INTER_PROFILE"
+
+	// DefaultProfile is used when neither the profile nor the default of
+	// the configuration file are set.
+	DefaultProfile = "default"
+)
+
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile holds the settings shared by the command line tools. Empty fields
+// are not set.
+type Profile struct {
+	Cert         string   `toml:"cert"`
+	Key          string   `toml:"key"`
+	KeyName      string   `toml:"key_name"`
+	ClientID     string   `toml:"client_id"`
+	ClientSecret string   `toml:"client_secret"`
+	Scopes       []string `toml:"scopes"`
+	Account      string   `toml:"account"`
+	Vault        string   `toml:"vault"`
+	TokenCache   string   `toml:"token_cache"`
+}
+
+// Config is a set of named profiles, such as:
+//
+//	default = "production"
+//
+//	[profiles.production]
+//	cert = "/etc/inter/cert.crt"
+//	key = "/etc/inter/cert.key"
+//	client_id = "..."
+//	scopes = ["extrato.read"]
+//
+//	[profiles.sandbox]
+//	cert = "sandbox.crt"
+//	key = "sandbox.key"
+type Config struct {
+	Default  string             `toml:"default"`
+	Profiles map[string]Profile `toml:"profiles"`
+}
+
+// DefaultPath returns the config.toml file inside the user configuration
+// directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "go-inter", "config.toml"), nil
+}
+
+// Load reads the configuration file, rejecting unknown keys.
+func Load(path string) (*Config, error) {
+	var c Config
+
+	md, err := toml.DecodeFile(path, &c)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	if keys := md.Undecoded(); len(keys) > 0 {
+		return nil, fmt.Errorf("could not parse %s: unknown key %s", path, keys[0])
+	}
+
+	return &c, nil
+}
+
+// Profile returns the named profile, or the default one when the name is
+// empty. The default profile may be missing, but a named one must exist.
+func (c *Config) Profile(name string) (Profile, error) {
+	explicit := name != ""
+
+	if name == "" {
+		name = c.Default
+		explicit = name != ""
+	}
+
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok && explicit {
+		return Profile{}, fmt.Errorf("%q: %w", name, ErrProfileNotFound)
+	}
+
+	return p, nil
+}
+
+// Names returns the profile names, sorted.
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// WithEnv returns the profile overridden by the INTER_* variables found by
+// lookup, usually os.LookupEnv. INTER_SCOPES is comma-separated.
+func (p Profile) WithEnv(lookup func(string) (string, bool)) Profile {
+	for _, v := range []struct {
+		name   string
+		target *string
+	}{
+		{"INTER_CERT", &p.Cert},
+		{"INTER_KEY", &p.Key},
+		{"INTER_KEY_NAME", &p.KeyName},
+		{"INTER_CLIENT_ID", &p.ClientID},
+		{"INTER_CLIENT_SECRET", &p.ClientSecret},
+		{"INTER_ACCOUNT", &p.Account},
+		{"INTER_VAULT", &p.Vault},
+		{"INTER_TOKEN_CACHE", &p.TokenCache},
+	} {
+		if s, ok := lookup(v.name); ok && s != "" {
+			*v.target = s
+		}
+	}
+
+	if s, ok := lookup("INTER_SCOPES"); ok && s != "" {
+		p.Scopes = strings.Split(s, ",")
+	}
+
+	return p
+}
+
+// Resolve returns the profile read from the configuration file and
+// overridden by the environment. An empty path or name falls back to
+// INTER_CONFIG and INTER_PROFILE. The default configuration file may be
+// missing, but a file set by path or INTER_CONFIG must exist.
+func Resolve(path, name string) (Profile, error) {
+	if path == "" {
+		path = os.Getenv(PathEnv)
+	}
+
+	if name == "" {
+		name = os.Getenv(ProfileEnv)
+	}
+
+	c := &Config{}
+
+	if path != "" {
+		var err error
+
+		c, err = Load(path)
+		if err != nil {
+			return Profile{}, err
+		}
+	} else if path, err := DefaultPath(); err == nil {
+		if v, err := Load(path); err == nil {
+			c = v
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return Profile{}, err
+		}
+	}
+
+	p, err := c.Profile(name)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	return p.WithEnv(os.LookupEnv), nil
+}
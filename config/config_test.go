@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = `
+default = "production"
+
+[profiles.production]
+cert = "/etc/inter/cert.crt"
+key = "/etc/inter/cert.key"
+client_id = "production-id"
+scopes = ["extrato.read", "boleto-cobranca.read"]
+account = "12345"
+
+[profiles.sandbox]
+cert = "sandbox.crt"
+key_name = "sandbox"
+client_id = "sandbox-id"
+vault = "sandbox.vault"
+`
+
+func writeConfig(t *testing.T, data string) string {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	c, err := Load(writeConfig(t, testConfig))
+	require.NoError(t, err)
+
+	require.Equal(t, "production", c.Default)
+	require.Equal(t, []string{"production", "sandbox"}, c.Names())
+
+	p, err := c.Profile("")
+	require.NoError(t, err)
+	require.Equal(t, Profile{
+		Cert:     "/etc/inter/cert.crt",
+		Key:      "/etc/inter/cert.key",
+		ClientID: "production-id",
+		Scopes:   []string{"extrato.read", "boleto-cobranca.read"},
+		Account:  "12345",
+	}, p)
+
+	p, err = c.Profile("sandbox")
+	require.NoError(t, err)
+	require.Equal(t, "sandbox", p.KeyName)
+	require.Equal(t, "sandbox.vault", p.Vault)
+
+	_, err = c.Profile("staging")
+	require.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestLoadUnknownKey(t *testing.T) {
+	_, err := Load(writeConfig(t, `
+[profiles.default]
+client_secret = "secret"
+certificate = "cert.crt"
+`))
+	require.ErrorContains(t, err, "unknown key profiles.default.certificate")
+}
+
+func TestProfileDefault(t *testing.T) {
+	c := &Config{}
+
+	p, err := c.Profile("")
+	require.NoError(t, err)
+	require.Equal(t, Profile{}, p)
+
+	c.Profiles = map[string]Profile{DefaultProfile: {ClientID: "id"}}
+
+	p, err = c.Profile("")
+	require.NoError(t, err)
+	require.Equal(t, "id", p.ClientID)
+
+	c.Default = "missing"
+
+	_, err = c.Profile("")
+	require.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestWithEnv(t *testing.T) {
+	env := map[string]string{
+		"INTER_CLIENT_ID": "env-id",
+		"INTER_SCOPES":    "extrato.read,pagamento-pix.write",
+		"INTER_ACCOUNT":   "",
+	}
+
+	p := Profile{ClientID: "id", Account: "12345", Cert: "cert.crt"}.WithEnv(func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	})
+
+	require.Equal(t, Profile{
+		Cert:     "cert.crt",
+		ClientID: "env-id",
+		Scopes:   []string{"extrato.read", "pagamento-pix.write"},
+		Account:  "12345",
+	}, p)
+}
+
+func TestResolve(t *testing.T) {
+	path := writeConfig(t, testConfig)
+
+	t.Setenv(PathEnv, path)
+	t.Setenv(ProfileEnv, "sandbox")
+	t.Setenv("INTER_CERT", "env.crt")
+
+	p, err := Resolve("", "")
+	require.NoError(t, err)
+	require.Equal(t, "sandbox-id", p.ClientID)
+	require.Equal(t, "env.crt", p.Cert)
+
+	p, err = Resolve(path, "production")
+	require.NoError(t, err)
+	require.Equal(t, "production-id", p.ClientID)
+
+	_, err = Resolve(filepath.Join(t.TempDir(), "missing.toml"), "")
+	require.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package inter
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidDocument = errors.New("invalid document")
+	ErrInvalidCPF      = errors.New("invalid cpf")
+	ErrInvalidCNPJ     = errors.New("invalid cnpj")
+)
+
+// Document is either a CPF or a CNPJ.
+type Document interface {
+	String() string
+	Format() string
+	Mask() string
+	Valid() bool
+}
+
+// ParseDocument parses s as a CPF or a CNPJ depending on its length.
+func ParseDocument(s string) (Document, error) {
+	n := normalizeDocument(s)
+
+	switch len(n) {
+	case cpfLength:
+		return ParseCPF(n)
+	case cnpjLength:
+		return ParseCNPJ(n)
+	}
+
+	return nil, ErrInvalidDocument
+}
+
+const cpfLength = 11
+
+// CPF holds the normalized representation of a CPF, with digits only.
+type CPF string
+
+func ParseCPF(s string) (CPF, error) {
+	c := CPF(normalizeDocument(s))
+	if !c.Valid() {
+		return "", ErrInvalidCPF
+	}
+
+	return c, nil
+}
+
+func (c CPF) String() string {
+	return string(c)
+}
+
+func (c CPF) Valid() bool {
+	s := string(c)
+
+	if len(s) != cpfLength || !isDigits(s) || allEqual(s) {
+		return false
+	}
+
+	return cpfCheckDigit(s[:9]) == s[9] && cpfCheckDigit(s[:10]) == s[10]
+}
+
+// Format returns the CPF in the 000.000.000-00 format.
+func (c CPF) Format() string {
+	if len(c) != cpfLength {
+		return string(c)
+	}
+
+	return string(c[0:3] + "." + c[3:6] + "." + c[6:9] + "-" + c[9:11])
+}
+
+// Mask hides the first and last digits of the CPF, which is suitable for
+// logs.
+func (c CPF) Mask() string {
+	if len(c) != cpfLength {
+		return strings.Repeat("*", len(c))
+	}
+
+	return "***." + string(c[3:6]) + "." + string(c[6:9]) + "-**"
+}
+
+func (c CPF) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+func (c *CPF) UnmarshalText(b []byte) error {
+	v, err := ParseCPF(string(b))
+	if err != nil {
+		return err
+	}
+
+	*c = v
+
+	return nil
+}
+
+func cpfCheckDigit(s string) byte {
+	sum := 0
+	weight := len(s) + 1
+
+	for i := 0; i < len(s); i++ {
+		sum += int(s[i]-'0') * weight
+		weight--
+	}
+
+	r := sum % 11
+	if r < 2 {
+		return '0'
+	}
+
+	return byte('0' + 11 - r)
+}
+
+const cnpjLength = 14
+
+// CNPJ holds the normalized representation of a CNPJ. Since July 2026 the
+// first twelve characters may be uppercase letters, while the two check
+// digits are always numeric.
+type CNPJ string
+
+func ParseCNPJ(s string) (CNPJ, error) {
+	c := CNPJ(normalizeDocument(s))
+	if !c.Valid() {
+		return "", ErrInvalidCNPJ
+	}
+
+	return c, nil
+}
+
+func (c CNPJ) String() string {
+	return string(c)
+}
+
+func (c CNPJ) Valid() bool {
+	s := string(c)
+
+	if len(s) != cnpjLength || !isDigits(s[12:]) || allEqual(s) {
+		return false
+	}
+
+	for i := 0; i < 12; i++ {
+		if !isCNPJChar(s[i]) {
+			return false
+		}
+	}
+
+	return cnpjCheckDigit(s[:12]) == s[12] && cnpjCheckDigit(s[:13]) == s[13]
+}
+
+// Alphanumeric reports whether the CNPJ uses the alphanumeric format.
+func (c CNPJ) Alphanumeric() bool {
+	return !isDigits(string(c))
+}
+
+// Format returns the CNPJ in the 00.000.000/0000-00 format.
+func (c CNPJ) Format() string {
+	if len(c) != cnpjLength {
+		return string(c)
+	}
+
+	return string(c[0:2] + "." + c[2:5] + "." + c[5:8] + "/" + c[8:12] + "-" + c[12:14])
+}
+
+// Mask hides the company root and the check digits of the CNPJ, which is
+// suitable for logs.
+func (c CNPJ) Mask() string {
+	if len(c) != cnpjLength {
+		return strings.Repeat("*", len(c))
+	}
+
+	return "**.***." + string(c[5:8]) + "/" + string(c[8:12]) + "-**"
+}
+
+func (c CNPJ) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+func (c *CNPJ) UnmarshalText(b []byte) error {
+	v, err := ParseCNPJ(string(b))
+	if err != nil {
+		return err
+	}
+
+	*c = v
+
+	return nil
+}
+
+// Characters are valued by their ASCII code minus 48, so digits keep their
+// value and letters start at 17.
+func cnpjCheckDigit(s string) byte {
+	sum := 0
+	weight := len(s) - 7
+
+	for i := 0; i < len(s); i++ {
+		sum += int(s[i]-'0') * weight
+
+		weight--
+		if weight < 2 {
+			weight = 9
+		}
+	}
+
+	r := sum % 11
+	if r < 2 {
+		return '0'
+	}
+
+	return byte('0' + 11 - r)
+}
+
+func isCNPJChar(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z')
+}
+
+// Removes the usual separators and uppercases letters.
+func normalizeDocument(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToUpper(strings.TrimSpace(s)) {
+		switch r {
+		case '.', '-', '/', ' ':
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func allEqual(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
@@ -0,0 +1,87 @@
+package inter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPF(t *testing.T) {
+	t.Run("returns an error if check digits are invalid", func(t *testing.T) {
+		_, err := ParseCPF("529.982.247-24")
+		require.ErrorIs(t, err, ErrInvalidCPF)
+	})
+
+	t.Run("returns an error if all digits are equal", func(t *testing.T) {
+		_, err := ParseCPF("111.111.111-11")
+		require.ErrorIs(t, err, ErrInvalidCPF)
+	})
+
+	t.Run("normalizes, formats and masks", func(t *testing.T) {
+		cpf, err := ParseCPF(" 529.982.247-25 ")
+		require.NoError(t, err)
+		require.Equal(t, cpf.String(), "52998224725")
+		require.Equal(t, cpf.Format(), "529.982.247-25")
+		require.Equal(t, cpf.Mask(), "***.982.247-**")
+	})
+
+	t.Run("validates when unmarshaling", func(t *testing.T) {
+		var v struct {
+			Payer CPF `json:"payer"`
+		}
+
+		err := json.Unmarshal([]byte(`{"payer": "529.982.247-25"}`), &v)
+		require.NoError(t, err)
+		require.Equal(t, v.Payer, CPF("52998224725"))
+
+		err = json.Unmarshal([]byte(`{"payer": "529.982.247-26"}`), &v)
+		require.ErrorIs(t, err, ErrInvalidCPF)
+	})
+}
+
+func TestCNPJ(t *testing.T) {
+	t.Run("returns an error if check digits are invalid", func(t *testing.T) {
+		_, err := ParseCNPJ("11.222.333/0001-82")
+		require.ErrorIs(t, err, ErrInvalidCNPJ)
+	})
+
+	t.Run("returns an error if check digits are not numeric", func(t *testing.T) {
+		_, err := ParseCNPJ("12.ABC.345/01DE-3A")
+		require.ErrorIs(t, err, ErrInvalidCNPJ)
+	})
+
+	t.Run("normalizes, formats and masks", func(t *testing.T) {
+		cnpj, err := ParseCNPJ("11.222.333/0001-81")
+		require.NoError(t, err)
+		require.False(t, cnpj.Alphanumeric())
+		require.Equal(t, cnpj.String(), "11222333000181")
+		require.Equal(t, cnpj.Format(), "11.222.333/0001-81")
+		require.Equal(t, cnpj.Mask(), "**.***.333/0001-**")
+	})
+
+	t.Run("accepts the alphanumeric format", func(t *testing.T) {
+		cnpj, err := ParseCNPJ("12.abc.345/01de-35")
+		require.NoError(t, err)
+		require.True(t, cnpj.Alphanumeric())
+		require.Equal(t, cnpj.String(), "12ABC34501DE35")
+		require.Equal(t, cnpj.Format(), "12.ABC.345/01DE-35")
+	})
+}
+
+func TestParseDocument(t *testing.T) {
+	t.Run("detects the document type", func(t *testing.T) {
+		doc, err := ParseDocument("529.982.247-25")
+		require.NoError(t, err)
+		require.IsType(t, doc, CPF(""))
+
+		doc, err = ParseDocument("11.222.333/0001-81")
+		require.NoError(t, err)
+		require.IsType(t, doc, CNPJ(""))
+	})
+
+	t.Run("returns an error if length is invalid", func(t *testing.T) {
+		_, err := ParseDocument("1234")
+		require.ErrorIs(t, err, ErrInvalidDocument)
+	})
+}
@@ -0,0 +1,52 @@
+package inter
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError is returned when the API answers with an unexpected status code.
+// Its message is the response body, which usually describes the problem.
+type APIError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the delay requested by the API before retrying, when
+	// informed.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return e.Body
+}
+
+func (e *APIError) RateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+func (e *APIError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// Invalid reports whether the API rejected the request itself, such as an
+// invalid date range or account, so retrying it would fail again.
+func (e *APIError) Invalid() bool {
+	return e.StatusCode >= 400 && e.StatusCode < 500 && !e.Unauthorized() && !e.RateLimited()
+}
+
+func newApiError(resp *http.Response, data []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(data),
+	}
+
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if sec, err := strconv.Atoi(v); err == nil {
+			e.RetryAfter = time.Duration(sec) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil {
+			e.RetryAfter = time.Until(t)
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,96 @@
+package inter
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Run("returns the status code and the retry delay", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, "too many requests")
+		}))
+		defer ts.Close()
+
+		client := NewClient(tls.Certificate{})
+		client.apiBaseUrl = ts.URL
+
+		banking := NewBanking(client, Token{})
+
+		_, err := banking.Transactions(context.Background(), time.Now(), time.Now())
+		require.EqualError(t, err, "too many requests")
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.True(t, apiErr.RateLimited())
+		require.False(t, apiErr.Unauthorized())
+		require.Equal(t, apiErr.RetryAfter, 30*time.Second)
+	})
+
+	t.Run("detects authorization failures", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer ts.Close()
+
+		client := NewClient(tls.Certificate{})
+		client.apiBaseUrl = ts.URL
+
+		banking := NewBanking(client, Token{})
+
+		_, err := banking.Balance(context.Background(), time.Now())
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.True(t, apiErr.Unauthorized())
+	})
+
+	t.Run("detects validation failures", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "invalid date range")
+		}))
+		defer ts.Close()
+
+		client := NewClient(tls.Certificate{})
+		client.apiBaseUrl = ts.URL
+
+		banking := NewBanking(client, Token{})
+
+		_, err := banking.Transactions(context.Background(), time.Now(), time.Now())
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.True(t, apiErr.Invalid())
+		require.False(t, apiErr.Unauthorized())
+		require.False(t, apiErr.RateLimited())
+	})
+
+	t.Run("returns authorization failures with the error title", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client", "error_title": "client not authorized"}`)
+		}))
+		defer ts.Close()
+
+		client := NewClient(tls.Certificate{})
+		client.apiBaseUrl = ts.URL
+
+		_, err := NewOAuth(client).Authorize(context.Background(), "client-id", "client-secret")
+		require.EqualError(t, err, "client not authorized")
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.True(t, apiErr.Unauthorized())
+	})
+}
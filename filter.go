@@ -0,0 +1,154 @@
+package inter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func ParseTransactionType(s string) (TransactionType, error) {
+	for _, t := range []TransactionType{
+		PixTransactionType,
+		PagamentoTransactionType,
+		TransferenciaTransactionType,
+	} {
+		if strings.EqualFold(s, t.String()) {
+			return t, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid transaction type %q", s)
+}
+
+func ParseTransactionOperation(s string) (TransactionOperation, error) {
+	for _, o := range []TransactionOperation{
+		CreditTransactionOperation,
+		DebitTransactionOperation,
+	} {
+		if strings.EqualFold(s, o.String()) {
+			return o, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid transaction operation %q", s)
+}
+
+// TransactionFilter reports whether a transaction must be kept.
+type TransactionFilter func(Transaction) bool
+
+// FilterTransactions returns the transactions matching all filters.
+func FilterTransactions(transactions []Transaction, filters ...TransactionFilter) []Transaction {
+	filtered := make([]Transaction, 0, len(transactions))
+
+	for _, v := range transactions {
+		if MatchAll(filters...)(v) {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered
+}
+
+func MatchAll(filters ...TransactionFilter) TransactionFilter {
+	return func(t Transaction) bool {
+		for _, f := range filters {
+			if !f(t) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+func TypeFilter(types ...TransactionType) TransactionFilter {
+	return func(t Transaction) bool {
+		for _, v := range types {
+			if t.Type == v {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func OperationFilter(operation TransactionOperation) TransactionFilter {
+	return func(t Transaction) bool {
+		return t.Operation == operation
+	}
+}
+
+func MinValueFilter(value float32) TransactionFilter {
+	return func(t Transaction) bool {
+		return Cents(t.Value) >= Cents(value)
+	}
+}
+
+func MaxValueFilter(value float32) TransactionFilter {
+	return func(t Transaction) bool {
+		return Cents(t.Value) <= Cents(value)
+	}
+}
+
+// MatchFilter keeps transactions whose title or description match re.
+func MatchFilter(re *regexp.Regexp) TransactionFilter {
+	return func(t Transaction) bool {
+		return re.MatchString(t.Title) || re.MatchString(t.Description)
+	}
+}
+
+// TransactionLess reports whether a must sort before b.
+type TransactionLess func(a, b Transaction) bool
+
+func ByDate(a, b Transaction) bool {
+	return a.Date.Before(b.Date)
+}
+
+func ByValue(a, b Transaction) bool {
+	return Cents(a.Value) < Cents(b.Value)
+}
+
+func ByTitle(a, b Transaction) bool {
+	return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+}
+
+func Descending(less TransactionLess) TransactionLess {
+	return func(a, b Transaction) bool {
+		return less(b, a)
+	}
+}
+
+// ParseTransactionLess parses a sort key, which can be "date", "value" or
+// "title", prefixed with a minus sign for descending order.
+func ParseTransactionLess(s string) (TransactionLess, error) {
+	key, desc := strings.CutPrefix(s, "-")
+
+	var less TransactionLess
+
+	switch key {
+	case "date":
+		less = ByDate
+	case "value":
+		less = ByValue
+	case "title":
+		less = ByTitle
+	default:
+		return nil, fmt.Errorf("invalid sort key %q", s)
+	}
+
+	if desc {
+		less = Descending(less)
+	}
+
+	return less, nil
+}
+
+// SortTransactions sorts the transactions in place, keeping the original
+// order of equal transactions.
+func SortTransactions(transactions []Transaction, less TransactionLess) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return less(transactions[i], transactions[j])
+	})
+}
@@ -0,0 +1,137 @@
+package inter
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransactionType(t *testing.T) {
+	t.Run("returns an error if type is invalid", func(t *testing.T) {
+		_, err := ParseTransactionType("wix")
+		require.Error(t, err)
+	})
+
+	t.Run("parses the type name", func(t *testing.T) {
+		got, err := ParseTransactionType("Pix")
+		require.NoError(t, err)
+		require.Equal(t, got, PixTransactionType)
+	})
+}
+
+func TestParseTransactionOperation(t *testing.T) {
+	t.Run("returns an error if operation is invalid", func(t *testing.T) {
+		_, err := ParseTransactionOperation("x")
+		require.Error(t, err)
+	})
+
+	t.Run("parses the operation name", func(t *testing.T) {
+		got, err := ParseTransactionOperation("debit")
+		require.NoError(t, err)
+		require.Equal(t, got, DebitTransactionOperation)
+	})
+}
+
+func filterTestTransactions() []Transaction {
+	date := time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)
+
+	return []Transaction{
+		{
+			Date:        date.AddDate(0, 0, 1),
+			Type:        PixTransactionType,
+			Operation:   CreditTransactionOperation,
+			Value:       100,
+			Title:       "Pix recebido",
+			Description: "PIX RECEBIDO - Cp :123-FULANO",
+		},
+		{
+			Date:        date,
+			Type:        PagamentoTransactionType,
+			Operation:   DebitTransactionOperation,
+			Value:       50.5,
+			Title:       "Pagamento efetuado",
+			Description: "DARF",
+		},
+		{
+			Date:        date.AddDate(0, 0, 2),
+			Type:        PixTransactionType,
+			Operation:   DebitTransactionOperation,
+			Value:       10,
+			Title:       "Pix enviado",
+			Description: "PIX ENVIADO - Cp :456-BELTRANO",
+		},
+	}
+}
+
+func TestFilterTransactions(t *testing.T) {
+	transactions := filterTestTransactions()
+
+	t.Run("keeps everything without filters", func(t *testing.T) {
+		got := FilterTransactions(transactions)
+		require.Equal(t, got, transactions)
+	})
+
+	t.Run("filters by type", func(t *testing.T) {
+		got := FilterTransactions(transactions, TypeFilter(PagamentoTransactionType))
+		require.Equal(t, got, transactions[1:2])
+	})
+
+	t.Run("filters by operation", func(t *testing.T) {
+		got := FilterTransactions(transactions, OperationFilter(CreditTransactionOperation))
+		require.Equal(t, got, transactions[0:1])
+	})
+
+	t.Run("filters by value range", func(t *testing.T) {
+		got := FilterTransactions(transactions, MinValueFilter(10), MaxValueFilter(50.5))
+		require.Equal(t, got, transactions[1:])
+	})
+
+	t.Run("filters by title or description", func(t *testing.T) {
+		got := FilterTransactions(transactions, MatchFilter(regexp.MustCompile(`(?i)beltrano|darf`)))
+		require.Equal(t, got, transactions[1:])
+	})
+
+	t.Run("combines filters", func(t *testing.T) {
+		got := FilterTransactions(transactions,
+			TypeFilter(PixTransactionType),
+			OperationFilter(DebitTransactionOperation))
+		require.Equal(t, got, transactions[2:])
+	})
+}
+
+func TestSortTransactions(t *testing.T) {
+	t.Run("returns an error if sort key is invalid", func(t *testing.T) {
+		_, err := ParseTransactionLess("amount")
+		require.Error(t, err)
+	})
+
+	tests := []struct {
+		key   string
+		order []float32
+	}{
+		{"date", []float32{50.5, 100, 10}},
+		{"-date", []float32{10, 100, 50.5}},
+		{"value", []float32{10, 50.5, 100}},
+		{"-value", []float32{100, 50.5, 10}},
+		{"title", []float32{50.5, 10, 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run("sorts by "+tt.key, func(t *testing.T) {
+			less, err := ParseTransactionLess(tt.key)
+			require.NoError(t, err)
+
+			transactions := filterTestTransactions()
+			SortTransactions(transactions, less)
+
+			got := make([]float32, 0, len(transactions))
+			for _, v := range transactions {
+				got = append(got, v.Value)
+			}
+
+			require.Equal(t, got, tt.order)
+		})
+	}
+}
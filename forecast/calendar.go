@@ -0,0 +1,87 @@
+package forecast
+
+import (
+	"time"
+)
+
+// Holidays returns the national bank holidays of the year in Brazil, when
+// banks do not settle payments. Carnival Monday and Tuesday are included,
+// as banks are closed on both days.
+func Holidays(year int) []time.Time {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	easter := Easter(year)
+
+	holidays := []time.Time{
+		date(time.January, 1),
+		easter.AddDate(0, 0, -48), // Carnival Monday
+		easter.AddDate(0, 0, -47), // Carnival Tuesday
+		easter.AddDate(0, 0, -2),  // Good Friday
+		date(time.April, 21),
+		date(time.May, 1),
+		easter.AddDate(0, 0, 60), // Corpus Christi
+		date(time.September, 7),
+		date(time.October, 12),
+		date(time.November, 2),
+		date(time.November, 15),
+	}
+
+	// Black Consciousness Day became a national holiday in 2024.
+	if year >= 2024 {
+		holidays = append(holidays, date(time.November, 20))
+	}
+
+	return append(holidays, date(time.December, 25))
+}
+
+// Easter returns the Easter Sunday of the year in the Gregorian calendar.
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func IsHoliday(date time.Time) bool {
+	for _, v := range Holidays(date.Year()) {
+		if v.Month() == date.Month() && v.Day() == date.Day() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func IsBusinessDay(date time.Time) bool {
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+
+	return !IsHoliday(date)
+}
+
+// NextBusinessDay returns the date itself if it is a business day, or the
+// following business day otherwise, as payments due on weekends and
+// holidays are settled on the next business day.
+func NextBusinessDay(date time.Time) time.Time {
+	for !IsBusinessDay(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return date
+}
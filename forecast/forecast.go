@@ -0,0 +1,217 @@
+package forecast
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/boleto"
+	"gopkg.in/yaml.v3"
+)
+
+// Flow is an expected credit or debit, such as an open receivable or a
+// scheduled payment.
+type Flow struct {
+	// Date the flow is due, settled on the next business day.
+	Date time.Time
+	// Amount is positive for receivables and negative for payments.
+	Amount      float32
+	Description string
+}
+
+// ScheduledFlow is a flow as written in a schedule file. Boletos set the
+// amount and the due date when they are not set, and their amount is a
+// payment unless the boleto is receivable.
+type ScheduledFlow struct {
+	Date        string   `yaml:"date" json:"date"`
+	Amount      *float32 `yaml:"amount" json:"amount"`
+	Boleto      string   `yaml:"boleto" json:"boleto"`
+	Receivable  bool     `yaml:"receivable" json:"receivable"`
+	Description string   `yaml:"description" json:"description"`
+}
+
+type Schedule struct {
+	Flows []ScheduledFlow `yaml:"flows" json:"flows"`
+}
+
+// Load reads the schedule from a JSON file, if its extension is .json, or
+// from a YAML file otherwise.
+func Load(path string) ([]Flow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Schedule
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&s)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&s)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse schedule: %w", err)
+	}
+
+	flows := make([]Flow, 0, len(s.Flows))
+
+	for i, v := range s.Flows {
+		f, err := v.Flow(time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("flow %d: %w", i+1, err)
+		}
+
+		flows = append(flows, f)
+	}
+
+	return flows, nil
+}
+
+// Flow returns the flow, resolving the boleto due date relative to the
+// reference date.
+func (s ScheduledFlow) Flow(ref time.Time) (Flow, error) {
+	f := Flow{Description: s.Description}
+
+	if s.Date != "" {
+		date, err := time.Parse(time.DateOnly, s.Date)
+		if err != nil {
+			return Flow{}, err
+		}
+
+		f.Date = date
+	}
+
+	if s.Amount != nil {
+		f.Amount = *s.Amount
+	}
+
+	if s.Boleto == "" {
+		if s.Date == "" {
+			return Flow{}, errors.New("date is required")
+		}
+
+		if s.Amount == nil {
+			return Flow{}, errors.New("amount is required")
+		}
+
+		return f, nil
+	}
+
+	b, err := boleto.Parse(s.Boleto)
+	if err != nil {
+		return Flow{}, err
+	}
+
+	if s.Date == "" {
+		date, ok := b.DueDateAt(ref)
+		if !ok {
+			return Flow{}, errors.New("date is required, the boleto has no due date")
+		}
+
+		f.Date = date
+	}
+
+	if s.Amount == nil {
+		// Collection boletos may carry a reference value instead of the
+		// amount.
+		if b.Kind == boleto.CollectionKind && !b.EffectiveAmount {
+			return Flow{}, errors.New("amount is required, the boleto has no amount")
+		}
+
+		f.Amount = inter.FromCents(b.Amount)
+	}
+
+	f.Amount = float32(math.Abs(float64(f.Amount)))
+	if !s.Receivable {
+		f.Amount = -f.Amount
+	}
+
+	if f.Description == "" {
+		f.Description = "boleto " + b.FormattedDigitableLine()
+	}
+
+	return f, nil
+}
+
+// Day is the projection of a day, with the flows settled on it and the
+// expected balance at its end.
+type Day struct {
+	Date     time.Time
+	Inflow   float32
+	Outflow  float32
+	Balance  float32
+	Flows    []Flow
+	Business bool
+}
+
+// Project returns the expected balance at the end of each day, starting on
+// the start date with the given balance. Flows are settled on the next
+// business day from their date and overdue flows on the first business
+// day, while flows after the last day are ignored.
+func Project(balance float32, start time.Time, days int, flows []Flow) []Day {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Flows are keyed by their day, since the same day may be at other
+	// times and locations.
+	settled := make(map[string][]Flow)
+
+	for _, f := range flows {
+		date := f.Date
+		if date.Before(start) {
+			date = start
+		}
+
+		key := NextBusinessDay(date).Format(time.DateOnly)
+		settled[key] = append(settled[key], f)
+	}
+
+	projection := make([]Day, 0, days)
+	current := inter.Cents(balance)
+
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i)
+
+		day := Day{
+			Date:     date,
+			Flows:    settled[date.Format(time.DateOnly)],
+			Business: IsBusinessDay(date),
+		}
+
+		sort.SliceStable(day.Flows, func(i, j int) bool {
+			return day.Flows[i].Date.Before(day.Flows[j].Date)
+		})
+
+		var inflow, outflow int64
+
+		for _, f := range day.Flows {
+			if c := inter.Cents(f.Amount); c > 0 {
+				inflow += c
+			} else {
+				outflow -= c
+			}
+		}
+
+		current += inflow - outflow
+
+		day.Inflow = inter.FromCents(inflow)
+		day.Outflow = inter.FromCents(outflow)
+		day.Balance = inter.FromCents(current)
+
+		projection = append(projection, day)
+	}
+
+	return projection
+}
@@ -0,0 +1,158 @@
+package forecast
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testBankDigitableLine = "00190500954014481606906809350314337370000000100"
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEaster(t *testing.T) {
+	require.Equal(t, date(2022, time.April, 17), Easter(2022))
+	require.Equal(t, date(2024, time.March, 31), Easter(2024))
+	require.Equal(t, date(2025, time.April, 20), Easter(2025))
+}
+
+func TestBusinessDays(t *testing.T) {
+	for _, v := range []time.Time{
+		date(2022, time.January, 1),
+		date(2022, time.February, 28), // Carnival
+		date(2022, time.March, 1),
+		date(2022, time.April, 15), // Good Friday
+		date(2022, time.June, 16),  // Corpus Christi
+		date(2024, time.November, 20),
+		date(2022, time.December, 25),
+	} {
+		require.True(t, IsHoliday(v), v)
+	}
+
+	require.False(t, IsHoliday(date(2022, time.November, 20)))
+
+	require.True(t, IsBusinessDay(date(2022, time.February, 2)))
+	require.False(t, IsBusinessDay(date(2022, time.February, 5)))
+
+	require.Equal(t, date(2022, time.February, 7), NextBusinessDay(date(2022, time.February, 5)))
+	require.Equal(t, date(2022, time.March, 2), NextBusinessDay(date(2022, time.February, 26)))
+	require.Equal(t, date(2022, time.February, 2), NextBusinessDay(date(2022, time.February, 2)))
+}
+
+func TestScheduledFlow(t *testing.T) {
+	amount := float32(-120.5)
+	ref := date(2007, time.December, 1)
+
+	t.Run("requires date and amount", func(t *testing.T) {
+		_, err := ScheduledFlow{Date: "2022-02-02"}.Flow(ref)
+		require.Error(t, err)
+
+		_, err = ScheduledFlow{Amount: &amount}.Flow(ref)
+		require.Error(t, err)
+
+		f, err := ScheduledFlow{Date: "2022-02-02", Amount: &amount, Description: "Rent"}.Flow(ref)
+		require.NoError(t, err)
+		require.Equal(t, Flow{Date: date(2022, time.February, 2), Amount: -120.5, Description: "Rent"}, f)
+	})
+
+	t.Run("uses the boleto amount and due date", func(t *testing.T) {
+		f, err := ScheduledFlow{Boleto: testBankDigitableLine}.Flow(ref)
+		require.NoError(t, err)
+		require.Equal(t, date(2007, time.December, 31), f.Date)
+		require.Equal(t, float32(-1), f.Amount)
+		require.Contains(t, f.Description, "boleto 00190.50095")
+
+		f, err = ScheduledFlow{Boleto: testBankDigitableLine, Amount: &amount, Receivable: true}.Flow(ref)
+		require.NoError(t, err)
+		require.Equal(t, float32(120.5), f.Amount)
+	})
+
+	t.Run("returns an error on invalid boletos", func(t *testing.T) {
+		_, err := ScheduledFlow{Boleto: "123"}.Flow(ref)
+		require.Error(t, err)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+flows:
+  - date: 2022-02-05
+    amount: 500
+    description: ACME invoice
+  - date: 2022-02-10
+    amount: -1200
+    description: Rent
+`), 0600))
+
+	flows, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, []Flow{
+		{Date: date(2022, time.February, 5), Amount: 500, Description: "ACME invoice"},
+		{Date: date(2022, time.February, 10), Amount: -1200, Description: "Rent"},
+	}, flows)
+
+	require.NoError(t, os.WriteFile(path, []byte("flows:\n  - dat: 2022-02-05\n"), 0600))
+
+	_, err = Load(path)
+	require.Error(t, err)
+}
+
+func TestProject(t *testing.T) {
+	flows := []Flow{
+		{Date: date(2022, time.January, 20), Amount: 100, Description: "overdue"},
+		{Date: date(2022, time.February, 5), Amount: 500.1, Description: "saturday"},
+		{Date: date(2022, time.February, 7), Amount: -200.2, Description: "monday"},
+		{Date: date(2022, time.February, 28), Amount: -50, Description: "carnival"},
+		{Date: date(2022, time.March, 10), Amount: 1000, Description: "after"},
+	}
+
+	// Friday, 2022-02-04.
+	days := Project(1000, date(2022, time.February, 4), 30, flows)
+	require.Len(t, days, 30)
+
+	require.Equal(t, date(2022, time.February, 4), days[0].Date)
+	require.True(t, days[0].Business)
+	require.Equal(t, []Flow{flows[0]}, days[0].Flows)
+	require.Equal(t, float32(1100), days[0].Balance)
+
+	require.False(t, days[1].Business)
+	require.Empty(t, days[1].Flows)
+	require.Equal(t, float32(1100), days[2].Balance)
+
+	monday := days[3]
+	require.Equal(t, date(2022, time.February, 7), monday.Date)
+	require.Equal(t, []Flow{flows[1], flows[2]}, monday.Flows)
+	require.Equal(t, float32(500.1), monday.Inflow)
+	require.Equal(t, float32(200.2), monday.Outflow)
+	require.Equal(t, float32(1399.9), monday.Balance)
+
+	// Carnival on Monday and Tuesday, settled on Wednesday.
+	wednesday := days[26]
+	require.Equal(t, date(2022, time.March, 2), wednesday.Date)
+	require.Equal(t, []Flow{flows[3]}, wednesday.Flows)
+	require.Equal(t, float32(1349.9), wednesday.Balance)
+
+	require.Equal(t, float32(1349.9), days[29].Balance)
+}
+
+func TestProjectLocalDates(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+
+	flows := []Flow{
+		{Date: time.Date(2022, time.February, 7, 10, 30, 0, 0, loc), Amount: 100, Description: "local"},
+		{Date: time.Date(2022, time.February, 8, 0, 0, 0, 0, loc), Amount: -40, Description: "midnight"},
+	}
+
+	days := Project(1000, date(2022, time.February, 7), 2, flows)
+
+	require.Equal(t, []Flow{flows[0]}, days[0].Flows)
+	require.Equal(t, float32(1100), days[0].Balance)
+	require.Equal(t, []Flow{flows[1]}, days[1].Flows)
+	require.Equal(t, float32(1060), days[1].Balance)
+}
@@ -0,0 +1,311 @@
+package authcmd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/certificate"
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/tokencache"
+	"github.com/agiacomolli/go-inter/vault"
+)
+
+var (
+	certFile        string
+	certFileUsage   = "signed certificate file, PEM bundle or PKCS#12 file"
+	defaultCertFile = "cert.crt"
+
+	keyFile        string
+	keyFileUsage   = "certificate private key file"
+	defaultKeyFile = "cert.key"
+
+	clientID        string
+	clientIDUsage   = "client identification"
+	defaultClientID = ""
+
+	clientSecret        string
+	clientSecretUsage   = "client secret, read from the vault when not set"
+	defaultClientSecret = ""
+
+	scopes        string
+	scopesUsage   = "comma-separated client scopes"
+	defaultScopes = ""
+
+	outputFormat        string
+	outputFormatUsage   = "output format; can be 'token', 'info' or 'json'"
+	defaultOutputFormat = "token"
+
+	account        string
+	accountUsage   = "checking account number of the cached token"
+	defaultAccount = ""
+
+	cacheDir        string
+	cacheDirUsage   = "token cache directory"
+	defaultCacheDir = tokenCacheDir()
+
+	noCache        bool
+	noCacheUsage   = "do not read nor write the token cache"
+	defaultNoCache = false
+
+	renew        bool
+	renewUsage   = "authorize a new token even if the cached one is valid"
+	defaultRenew = false
+
+	vaultPath        string
+	vaultPathUsage   = "encrypted vault with the client secret, private key and cached tokens"
+	defaultVaultPath = ""
+
+	keyName        string
+	keyNameUsage   = "name of the private key in the vault, used instead of --key"
+	defaultKeyName = ""
+)
+
+// Command returns the authorization command tree, named as the program or
+// the parent command running it. It authorizes and prints a token when no
+// subcommand is given.
+func Command(name string) *cli.Command {
+	return &cli.Command{
+		Name:  name,
+		Short: "authorize client credentials and manage secrets",
+		Long: "Authorize the client credentials and print the token, reading a valid " +
+			"token from the token cache, or from the vault when set, so the banking " +
+			"commands can use it.",
+		Flags:  authFlags,
+		Before: setup,
+		Run:    runToken,
+		Commands: []*cli.Command{
+			vaultCommand(),
+			certInfoCommand(),
+		},
+	}
+}
+
+func authFlags(flag *flag.FlagSet) {
+	flag.StringVar(&certFile, "cert", defaultCertFile, certFileUsage)
+	flag.StringVar(&keyFile, "key", defaultKeyFile, keyFileUsage)
+	flag.StringVar(&clientID, "client-id", defaultClientID, clientIDUsage)
+	flag.StringVar(&clientSecret, "client-secret", defaultClientSecret, clientSecretUsage)
+	flag.StringVar(&scopes, "scopes", defaultScopes, scopesUsage)
+	flag.StringVar(&outputFormat, "output-format", defaultOutputFormat, outputFormatUsage)
+	flag.StringVar(&account, "account", defaultAccount, accountUsage)
+	flag.StringVar(&cacheDir, "cache-dir", defaultCacheDir, cacheDirUsage)
+	flag.BoolVar(&noCache, "no-cache", defaultNoCache, noCacheUsage)
+	flag.BoolVar(&renew, "renew", defaultRenew, renewUsage)
+	flag.StringVar(&vaultPath, "vault", defaultVaultPath, vaultPathUsage)
+	flag.StringVar(&keyName, "key-name", defaultKeyName, keyNameUsage)
+	flag.StringVar(&profile, "profile", defaultProfile, profileUsage)
+	flag.StringVar(&configPath, "config", defaultConfigPath, configPathUsage)
+}
+
+// setup reads the configuration shared by every command.
+func setup(ctx context.Context, f *flag.FlagSet) error {
+	if err := applyProfile(f); err != nil {
+		return fmt.Errorf("could not read configuration: %w", err)
+	}
+
+	if _, err := inter.ParseScopes(scopes); err != nil {
+		return cli.Usagef("invalid scopes: %s", err)
+	}
+
+	return nil
+}
+
+// openCertificate opens the vault when set and loads the client
+// certificate.
+func openCertificate() (*vault.Vault, tls.Certificate, error) {
+	var secrets *vault.Vault
+
+	if vaultPath != "" {
+		var err error
+
+		secrets, err = vault.OpenPrompt(vaultPath)
+		if err != nil {
+			return nil, tls.Certificate{}, fmt.Errorf("could not open vault: %w", err)
+		}
+	}
+
+	cert, err := loadCertificate(secrets)
+	if err != nil {
+		return nil, tls.Certificate{}, fmt.Errorf("could not parse certificate files: %w", err)
+	}
+
+	return secrets, cert, nil
+}
+
+func runToken(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return cli.Usagef("command not found: %s", args[0])
+	}
+
+	secrets, cert, err := openCertificate()
+	if err != nil {
+		return err
+	}
+
+	if clientID == "" {
+		return cli.Usagef("client-id is required")
+	}
+
+	if clientSecret == "" && secrets != nil {
+		clientSecret, err = secrets.ClientSecret(clientID)
+		if err != nil {
+			return fmt.Errorf("could not read client secret: %w", err)
+		}
+	}
+
+	if clientSecret == "" {
+		return cli.Usagef("client-secret is required")
+	}
+
+	client := inter.NewClient(cert)
+
+	oauth := inter.NewOAuth(client)
+
+	authorize := func(ctx context.Context) (inter.Token, error) {
+		return oauth.Authorize(ctx, clientID, clientSecret,
+			strings.Split(scopes, ",")...)
+	}
+
+	switch outputFormat {
+	case "token", "info", "json":
+	default:
+		return cli.Usagef("invalid output format")
+	}
+
+	token, err := cachedToken(ctx, secrets, authorize)
+	if err != nil {
+		return fmt.Errorf("could not authorize: %w", err)
+	}
+
+	var output strings.Builder
+
+	switch outputFormat {
+	case "token":
+		_, err = fmt.Fprint(&output, token.Data)
+	case "info":
+		err = writeInfoOutput(&output, token)
+	case "json":
+		err = writeJsonOutput(&output, token)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not print output: %w", err)
+	}
+
+	fmt.Println(output.String())
+
+	return nil
+}
+
+func tokenCacheDir() string {
+	dir, err := tokencache.DefaultDir()
+	if err != nil {
+		return "tokens"
+	}
+
+	return dir
+}
+
+// loadCertificate reads the private key from the vault when its name is
+// set, or from the key file otherwise.
+func loadCertificate(secrets *vault.Vault) (tls.Certificate, error) {
+	if keyName == "" {
+		return certificate.Load(certFile, keyFile, os.Getenv("INTER_CERT_PASSWORD"))
+	}
+
+	if secrets == nil {
+		return tls.Certificate{}, errors.New("vault is required to read the private key")
+	}
+
+	return secrets.LoadX509KeyPair(certFile, keyName)
+}
+
+// cachedToken returns a valid cached token, or authorizes a new one and
+// writes it to the cache, so inter-banking can read it. Tokens are cached in
+// the vault when it is open.
+func cachedToken(ctx context.Context, secrets *vault.Vault, authorize func(context.Context) (inter.Token, error)) (inter.Token, error) {
+	if noCache {
+		return authorize(ctx)
+	}
+
+	key := tokencache.Key{
+		ClientID: clientID,
+		Scopes:   strings.Split(scopes, ","),
+		Account:  account,
+	}
+
+	if secrets != nil {
+		return vaultToken(ctx, secrets, key, authorize)
+	}
+
+	cache, err := tokencache.Open(cacheDir)
+	if err != nil {
+		return inter.Token{}, err
+	}
+
+	if !renew {
+		return cache.Token(ctx, key, authorize)
+	}
+
+	token, err := authorize(ctx)
+	if err != nil {
+		return inter.Token{}, err
+	}
+
+	return token, cache.Save(key, token)
+}
+
+func vaultToken(ctx context.Context, secrets *vault.Vault, key tokencache.Key, authorize func(context.Context) (inter.Token, error)) (inter.Token, error) {
+	if !renew {
+		token, ok, err := secrets.Token(key.ID())
+		if err != nil {
+			return inter.Token{}, err
+		}
+
+		if ok && token.ValidFor(time.Now(), inter.TokenExpiryMargin) {
+			return token, nil
+		}
+	}
+
+	token, err := authorize(ctx)
+	if err != nil {
+		return inter.Token{}, err
+	}
+
+	if err := secrets.SetToken(key.ID(), token); err != nil {
+		return inter.Token{}, err
+	}
+
+	return token, secrets.Save()
+}
+
+func writeInfoOutput(w io.Writer, t inter.Token) error {
+	_, err := fmt.Fprintf(w, `token     %s
+type      %s
+expires   %s
+scopes    %s`,
+		t.Data, t.Type, t.ExpiresAt.Format(time.RFC3339),
+		strings.Join(t.Scopes, " "))
+
+	return err
+}
+
+func writeJsonOutput(w io.Writer, t inter.Token) error {
+	b, err := json.MarshalIndent(t, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(w, string(b))
+
+	return err
+}
@@ -0,0 +1,107 @@
+package authcmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter/internal/cli"
+)
+
+type certInfo struct {
+	Subject      string `json:"subject"`
+	Issuer       string `json:"issuer"`
+	SerialNumber string `json:"serial_number"`
+	NotBefore    string `json:"not_before"`
+	NotAfter     string `json:"not_after"`
+	DaysLeft     int    `json:"days_left"`
+	Fingerprint  string `json:"sha256_fingerprint"`
+}
+
+// errExpired makes cert-info exit with an error status after printing the
+// details of an expired certificate.
+var errExpired = errors.New("certificate expired")
+
+func certInfoCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "cert-info",
+		Short: "show the client certificate details",
+		Long: "Show the subject, issuer, validity and fingerprint of the client " +
+			"certificate, exiting with an error status when it is expired.",
+		Run: runCertInfo,
+	}
+}
+
+func runCertInfo(ctx context.Context, args []string) error {
+	_, cert, err := openCertificate()
+	if err != nil {
+		return err
+	}
+
+	leaf := cert.Leaf
+	sum := sha256.Sum256(leaf.Raw)
+
+	info := certInfo{
+		Subject:      leaf.Subject.String(),
+		Issuer:       leaf.Issuer.String(),
+		SerialNumber: leaf.SerialNumber.String(),
+		NotBefore:    leaf.NotBefore.Format(time.RFC3339),
+		NotAfter:     leaf.NotAfter.Format(time.RFC3339),
+		DaysLeft:     int(time.Until(leaf.NotAfter).Hours() / 24),
+		Fingerprint:  hex.EncodeToString(sum[:]),
+	}
+
+	var output strings.Builder
+
+	switch outputFormat {
+	case "token", "info":
+		err = writeCertInfoOutput(&output, info)
+	case "json":
+		err = writeCertJsonOutput(&output, info)
+	default:
+		return cli.Usagef("invalid output format")
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not print output: %w", err)
+	}
+
+	fmt.Println(output.String())
+
+	if time.Now().After(leaf.NotAfter) {
+		return errExpired
+	}
+
+	return nil
+}
+
+func writeCertInfoOutput(w io.Writer, info certInfo) error {
+	_, err := fmt.Fprintf(w, `subject      %s
+issuer       %s
+serial       %s
+not before   %s
+not after    %s
+days left    %d
+fingerprint  %s`,
+		info.Subject, info.Issuer, info.SerialNumber, info.NotBefore,
+		info.NotAfter, info.DaysLeft, info.Fingerprint)
+
+	return err
+}
+
+func writeCertJsonOutput(w io.Writer, info certInfo) error {
+	b, err := json.MarshalIndent(info, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(w, string(b))
+
+	return err
+}
@@ -0,0 +1,55 @@
+package authcmd
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/agiacomolli/go-inter/config"
+)
+
+var (
+	profile        string
+	profileUsage   = "configuration profile (defaults to the default profile of the configuration file)"
+	defaultProfile = ""
+
+	configPath        string
+	configPathUsage   = "configuration file (defaults to config.toml in the user configuration directory)"
+	defaultConfigPath = ""
+)
+
+// applyProfile sets the flags not given on the command line from the
+// environment and the configuration profile, so flags take precedence over
+// the environment, then the profile and then the defaults.
+func applyProfile(f *flag.FlagSet) error {
+	p, err := config.Resolve(configPath, profile)
+	if err != nil {
+		return err
+	}
+
+	set := make(map[string]bool)
+	f.Visit(func(fl *flag.Flag) {
+		set[fl.Name] = true
+	})
+
+	for _, v := range []struct {
+		name   string
+		target *string
+		value  string
+	}{
+		{"cert", &certFile, p.Cert},
+		{"key", &keyFile, p.Key},
+		{"key-name", &keyName, p.KeyName},
+		{"client-id", &clientID, p.ClientID},
+		{"client-secret", &clientSecret, p.ClientSecret},
+		{"scopes", &scopes, strings.Join(p.Scopes, ",")},
+		{"account", &account, p.Account},
+		{"vault", &vaultPath, p.Vault},
+		{"cache-dir", &cacheDir, p.TokenCache},
+	} {
+		if v.value != "" && !set[v.name] {
+			*v.target = v.value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package authcmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/vault"
+	"golang.org/x/term"
+)
+
+var (
+	secretFile        string
+	secretFileUsage   = "file with the secret, read from the standard input when not set"
+	defaultSecretFile = ""
+)
+
+func vaultCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "vault",
+		Short: "manage the encrypted vault",
+		Long: "Manage the encrypted vault with the client secrets, private keys and " +
+			"cached tokens. The passphrase is read from " + vault.PassphraseEnv +
+			" or prompted for.",
+		Commands: []*cli.Command{
+			{
+				Name:  "init",
+				Short: "create an empty vault",
+				Run:   withVaultPath(vaultInit),
+			},
+			{
+				Name:  "add",
+				Args:  "<kind> <name>",
+				Short: "add a client secret or a private key",
+				Flags: func(flag *flag.FlagSet) {
+					flag.StringVar(&secretFile, "file", defaultSecretFile, secretFileUsage)
+				},
+				Run: withVaultPath(vaultAdd),
+			},
+			{
+				Name:  "list",
+				Short: "list the secrets",
+				Run:   withVaultPath(vaultList),
+			},
+			{
+				Name:  "remove",
+				Args:  "<kind> <name>",
+				Short: "remove a secret",
+				Run:   withVaultPath(vaultRemove),
+			},
+		},
+	}
+}
+
+// withVaultPath runs the command with the vault path, the default one when
+// not set.
+func withVaultPath(run func(path string, args []string) error) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		path := vaultPath
+		if path == "" {
+			var err error
+
+			path, err = vault.DefaultPath()
+			if err != nil {
+				return fmt.Errorf("could not find vault: %w", err)
+			}
+		}
+
+		return run(path, args)
+	}
+}
+
+func vaultInit(path string, args []string) error {
+	if len(args) > 0 {
+		return cli.Usagef("unexpected arguments: %s", strings.Join(args, " "))
+	}
+
+	passphrase, ok := os.LookupEnv(vault.PassphraseEnv)
+	if !ok {
+		var err error
+
+		passphrase, err = vault.ReadSecret("New vault passphrase: ")
+		if err != nil {
+			return fmt.Errorf("could not read passphrase: %w", err)
+		}
+
+		confirm, err := vault.ReadSecret("Confirm passphrase: ")
+		if err != nil {
+			return fmt.Errorf("could not read passphrase: %w", err)
+		}
+
+		if passphrase != confirm {
+			return errors.New("passphrases do not match")
+		}
+	}
+
+	if _, err := vault.Create(path, passphrase); err != nil {
+		return fmt.Errorf("could not create vault: %w", err)
+	}
+
+	fmt.Printf("Created vault %s\n", path)
+
+	return nil
+}
+
+func parseSecretArgs(args []string) (vault.Kind, string, error) {
+	if len(args) != 2 {
+		return "", "", cli.Usagef("secret kind and name are required")
+	}
+
+	kind, err := vault.ParseKind(args[0])
+	if err != nil {
+		return "", "", &cli.UsageError{Err: err}
+	}
+
+	return kind, args[1], nil
+}
+
+func vaultAdd(path string, args []string) error {
+	kind, name, err := parseSecretArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if kind == vault.TokenKind {
+		return cli.Usagef("tokens are added when authorizing with the vault")
+	}
+
+	var data []byte
+
+	switch {
+	case secretFile != "":
+		data, err = os.ReadFile(secretFile)
+	case term.IsTerminal(int(os.Stdin.Fd())):
+		var s string
+
+		s, err = vault.ReadSecret(fmt.Sprintf("%s %s: ", kind, name))
+		data = []byte(s)
+	default:
+		data, err = io.ReadAll(os.Stdin)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not read secret: %w", err)
+	}
+
+	v, err := vault.OpenPrompt(path)
+	if err != nil {
+		return fmt.Errorf("could not open vault: %w", err)
+	}
+
+	v.Set(kind, name, data)
+
+	return v.Save()
+}
+
+func vaultList(path string, args []string) error {
+	if len(args) > 0 {
+		return cli.Usagef("unexpected arguments: %s", strings.Join(args, " "))
+	}
+
+	v, err := vault.OpenPrompt(path)
+	if err != nil {
+		return fmt.Errorf("could not open vault: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 5, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Kind\tName")
+
+	for _, s := range v.List() {
+		fmt.Fprintf(tw, "%s\t%s\n", s.Kind, s.Name)
+	}
+
+	return tw.Flush()
+}
+
+func vaultRemove(path string, args []string) error {
+	kind, name, err := parseSecretArgs(args)
+	if err != nil {
+		return err
+	}
+
+	v, err := vault.OpenPrompt(path)
+	if err != nil {
+		return fmt.Errorf("could not open vault: %w", err)
+	}
+
+	if !v.Remove(kind, name) {
+		return fmt.Errorf("%s %s: %w", kind, name, vault.ErrNotFound)
+	}
+
+	return v.Save()
+}
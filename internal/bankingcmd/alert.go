@@ -0,0 +1,67 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/alert"
+	"github.com/agiacomolli/go-inter/internal/cli"
+)
+
+var (
+	alertRules        string
+	alertRulesUsage   = "alert rules file in YAML or JSON"
+	defaultAlertRules = ""
+)
+
+func alertCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "alert",
+		Short: "alert on balance thresholds",
+		Flags: alertFlags,
+		Run:   withClient(runAlert),
+	}
+}
+
+func alertFlags(flag *flag.FlagSet) {
+	flag.StringVar(&alertRules, "rules", defaultAlertRules, alertRulesUsage)
+	flag.DurationVar(&interval, "i", alert.DefaultInterval, intervalUsage)
+	flag.DurationVar(&interval, "interval", alert.DefaultInterval, intervalUsage)
+	addSinkFlags(flag)
+	addCredentialsFlags(flag)
+}
+
+func runAlert(ctx context.Context, client *inter.Client, token inter.Token, args []string) error {
+	if alertRules == "" {
+		return cli.Usagef("rules file is required")
+	}
+
+	c, err := alert.Load(alertRules)
+	if err != nil {
+		return fmt.Errorf("could not load alerts: %w", err)
+	}
+
+	connect, err := newConnector(client, token)
+	if err != nil {
+		return err
+	}
+
+	m, err := alert.New(connect, newSink(), c, alert.Options{
+		Interval: interval,
+		OnError: func(err error) {
+			fmt.Fprintln(os.Stderr, err)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("invalid alerts: %w", err)
+	}
+
+	if err := m.Run(ctx); err != nil {
+		return fmt.Errorf("could not check alerts: %w", err)
+	}
+
+	return nil
+}
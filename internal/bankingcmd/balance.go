@@ -1,4 +1,4 @@
-package main
+package bankingcmd
 
 import (
 	"context"
@@ -10,29 +10,37 @@ import (
 	"time"
 
 	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/internal/cli"
 )
 
 var (
 	date        string
-	dateUsage   = "balance date"
+	dateUsage   = "balance date in the format YYYY-MM-DD (defaults to today)"
 	defaultDate = ""
 
 	outputFormat        string
-	outputFormatUsage   = "output format"
+	outputFormatUsage   = "the table format used to show balance; can be 'short' or 'full'"
 	defaultOutputFormat = "short"
 )
 
-func balanceCommand(ctx context.Context, banking *inter.Banking, args []string) {
-	flag := flag.NewFlagSet("balance", flag.ExitOnError)
+func balanceCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "balance",
+		Short: "get account balance",
+		Flags: balanceFlags,
+		Run:   withBanking(runBalance),
+	}
+}
 
+func balanceFlags(flag *flag.FlagSet) {
 	flag.StringVar(&date, "d", defaultDate, dateUsage)
 	flag.StringVar(&date, "date", defaultDate, dateUsage)
 	flag.StringVar(&outputFormat, "f", defaultOutputFormat, outputFormatUsage)
 	flag.StringVar(&outputFormat, "output-format", defaultOutputFormat, outputFormatUsage)
+	flag.StringVar(&format, "format", format, commandFormatUsage)
+}
 
-	flag.Usage = mainUsage
-	flag.Parse(args)
-
+func runBalance(ctx context.Context, banking *inter.Banking, args []string) error {
 	var balanceDate time.Time
 	if date == "" {
 		balanceDate = time.Now()
@@ -40,15 +48,26 @@ func balanceCommand(ctx context.Context, banking *inter.Banking, args []string)
 		var err error
 		balanceDate, err = time.Parse(time.DateOnly, date)
 		if err != nil {
-			fmt.Printf("could not parse balance date: %s\n", err)
-			os.Exit(1)
+			return cli.Usagef("could not parse balance date: %s", err)
 		}
 	}
 
 	balance, err := banking.Balance(ctx, balanceDate)
 	if err != nil {
-		fmt.Printf("could not get balance: %s\n", err)
-		os.Exit(1)
+		return fmt.Errorf("could not get balance: %w", err)
+	}
+
+	if isRecordFormat(format) {
+		err = writeRecord(os.Stdout, format, newBalanceRecord(balanceDate, balance))
+		if err != nil {
+			return fmt.Errorf("could not write balance: %w", err)
+		}
+
+		return nil
+	}
+
+	if format != "table" {
+		return cli.Usagef("invalid output format")
 	}
 
 	switch outputFormat {
@@ -66,8 +85,8 @@ func balanceCommand(ctx context.Context, banking *inter.Banking, args []string)
 		tw.Flush()
 		fmt.Println(payload.String())
 	default:
-		fmt.Println("invalid output format")
-		os.Exit(1)
+		return cli.Usagef("invalid output format")
 	}
 
+	return nil
 }
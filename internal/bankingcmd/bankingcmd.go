@@ -0,0 +1,145 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/certificate"
+	"github.com/agiacomolli/go-inter/internal/cli"
+)
+
+var (
+	certFile        string
+	certFileUsage   = "signed certificate file, PEM bundle or PKCS#12 file"
+	defaultCertFile = "cert.crt"
+
+	keyFile        string
+	keyFileUsage   = "certificate private key file"
+	defaultKeyFile = "cert.key"
+
+	tokenData        string
+	tokenDataUsage   = "personal user token, read from the token cache when not set"
+	defaultTokenData = ""
+
+	account        string
+	accountUsage   = "checking account number, required when the application has access to more than one account"
+	defaultAccount = ""
+
+	format        string
+	formatUsage   = "the output format of every command; can be 'table', 'csv', 'json' or 'ndjson'"
+	defaultFormat = "table"
+
+	// Subcommands accept the output format after their name as well.
+	commandFormatUsage = "overrides the global output format"
+)
+
+// Command returns the banking command tree, named as the program or the
+// parent command running it.
+func Command(name string) *cli.Command {
+	return &cli.Command{
+		Name:  name,
+		Short: "query balances and statements of the checking account",
+		Long: "Query balances and statements of the checking account. Without a token, " +
+			"the token is read from the token cache, or from the vault when set, given " +
+			"the client identification, scopes and account.",
+		Flags:  globalFlags,
+		Before: setup,
+		Commands: []*cli.Command{
+			balanceCommand(),
+			statementCommand(),
+			summaryCommand(),
+			syncCommand(),
+			forecastCommand(),
+			reconcileCommand(),
+			watchCommand(),
+			alertCommand(),
+			tuiCommand(),
+		},
+	}
+}
+
+func globalFlags(flag *flag.FlagSet) {
+	flag.StringVar(&certFile, "c", defaultCertFile, certFileUsage)
+	flag.StringVar(&certFile, "cert", defaultCertFile, certFileUsage)
+	flag.StringVar(&keyFile, "k", defaultKeyFile, keyFileUsage)
+	flag.StringVar(&keyFile, "key", defaultKeyFile, keyFileUsage)
+	flag.StringVar(&tokenData, "t", defaultTokenData, tokenDataUsage)
+	flag.StringVar(&tokenData, "token", defaultTokenData, tokenDataUsage)
+	flag.StringVar(&account, "a", defaultAccount, accountUsage)
+	flag.StringVar(&account, "account", defaultAccount, accountUsage)
+	flag.StringVar(&format, "format", defaultFormat, formatUsage)
+	flag.StringVar(&clientID, "client-id", defaultClientID, clientIDUsage)
+	flag.StringVar(&clientSecret, "client-secret", defaultClientSecret, clientSecretUsage)
+	flag.StringVar(&scopes, "scopes", defaultScopes, scopesUsage)
+	flag.StringVar(&tokenCacheDir, "token-cache", defaultTokenCacheDir, tokenCacheDirUsage)
+	flag.StringVar(&vaultPath, "vault", defaultVaultPath, vaultPathUsage)
+	flag.StringVar(&keyName, "key-name", defaultKeyName, keyNameUsage)
+	flag.StringVar(&profile, "profile", defaultProfile, profileUsage)
+	flag.StringVar(&configPath, "config", defaultConfigPath, configPathUsage)
+}
+
+var (
+	client   *inter.Client
+	reloader *certificate.Reloader
+)
+
+// setup reads the configuration, the vault and the certificate shared by
+// every command.
+func setup(ctx context.Context, f *flag.FlagSet) error {
+	if err := applyProfile(f); err != nil {
+		return fmt.Errorf("could not read configuration: %w", err)
+	}
+
+	if _, err := inter.ParseScopes(scopes); err != nil {
+		return cli.Usagef("invalid scopes: %s", err)
+	}
+
+	if err := openVault(); err != nil {
+		return fmt.Errorf("could not open vault: %w", err)
+	}
+
+	var err error
+
+	reloader, err = newReloader()
+	if err != nil {
+		return fmt.Errorf("could not parse certificate files: %w", err)
+	}
+
+	client = inter.NewClientFunc(reloader.GetClientCertificate)
+
+	return nil
+}
+
+// withBanking runs the command with the service of the user token.
+func withBanking(run func(ctx context.Context, banking *inter.Banking, args []string) error) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		token, err := userToken(ctx, client)
+		if err != nil {
+			return fmt.Errorf("could not get token: %w", err)
+		}
+
+		// Cached tokens know their scopes, so missing ones are reported
+		// before calling the API.
+		banking := inter.NewBanking(client, token).WithScopeCheck()
+		if account != "" {
+			banking = banking.WithAccount(account)
+		}
+
+		return run(ctx, banking, args)
+	}
+}
+
+// withClient runs the long running commands, which may issue their own
+// tokens from the client credentials, reloading the certificate when it is
+// rotated.
+func withClient(run func(ctx context.Context, client *inter.Client, token inter.Token, args []string) error) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		token, _ := userToken(ctx, client)
+
+		go reloader.Run(ctx)
+
+		return run(ctx, client, token, args)
+	}
+}
@@ -0,0 +1,40 @@
+package bankingcmd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agiacomolli/go-inter/certificate"
+)
+
+// newReloader loads the client certificate, reloading it when its files
+// change while watch and alert run, and warning when it is about to expire.
+func newReloader() (*certificate.Reloader, error) {
+	files := []string{certFile}
+	if keyName == "" {
+		files = append(files, keyFile)
+	}
+
+	return certificate.NewReloader(loadCertificate, certificate.ReloaderOptions{
+		Files: files,
+		OnReload: func(leaf *x509.Certificate) {
+			fmt.Fprintf(os.Stderr, "certificate reloaded, expires at %s\n",
+				leaf.NotAfter.Format(time.RFC3339))
+		},
+		OnExpiring: func(leaf *x509.Certificate, remaining time.Duration) {
+			if remaining <= 0 {
+				fmt.Fprintf(os.Stderr, "warning: certificate expired at %s\n",
+					leaf.NotAfter.Format(time.RFC3339))
+				return
+			}
+
+			fmt.Fprintf(os.Stderr, "warning: certificate expires in %d days, at %s\n",
+				int(remaining.Hours()/24), leaf.NotAfter.Format(time.RFC3339))
+		},
+		OnError: func(err error) {
+			fmt.Fprintf(os.Stderr, "could not reload certificate: %s\n", err)
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package bankingcmd
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/category"
+)
+
+var (
+	types        string
+	typesUsage   = "comma-separated transaction types to show; can be 'pix', 'pagamento' or 'transferencia'"
+	defaultTypes = ""
+
+	operation        string
+	operationUsage   = "transaction operation to show; can be 'credit' or 'debit'"
+	defaultOperation = ""
+
+	minValue        string
+	minValueUsage   = "minimum transaction value"
+	defaultMinValue = ""
+
+	maxValue        string
+	maxValueUsage   = "maximum transaction value"
+	defaultMaxValue = ""
+
+	match        string
+	matchUsage   = "regular expression matching the transaction title or description"
+	defaultMatch = ""
+
+	rulesFile        string
+	rulesFileUsage   = "categorization rules file in YAML or JSON, adding a category to each transaction"
+	defaultRulesFile = ""
+)
+
+func addFilterFlags(flag *flag.FlagSet) {
+	flag.StringVar(&types, "type", defaultTypes, typesUsage)
+	flag.StringVar(&operation, "operation", defaultOperation, operationUsage)
+	flag.StringVar(&minValue, "min", defaultMinValue, minValueUsage)
+	flag.StringVar(&maxValue, "max", defaultMaxValue, maxValueUsage)
+	flag.StringVar(&match, "match", defaultMatch, matchUsage)
+}
+
+func addRulesFlag(flag *flag.FlagSet) {
+	flag.StringVar(&rulesFile, "rules", defaultRulesFile, rulesFileUsage)
+}
+
+// Returns nil when no rules file is set.
+func transactionCategorizer() (func(inter.Transaction) string, error) {
+	if rulesFile == "" {
+		return nil, nil
+	}
+
+	engine, err := category.Load(rulesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.Categorize, nil
+}
+
+// filtersSet reports whether any transaction filter was given.
+func filtersSet() bool {
+	return types != "" || operation != "" || minValue != "" || maxValue != "" || match != ""
+}
+
+func transactionFilter() (inter.TransactionFilter, error) {
+	var filters []inter.TransactionFilter
+
+	if types != "" {
+		var tt []inter.TransactionType
+
+		for _, v := range strings.Split(types, ",") {
+			t, err := inter.ParseTransactionType(strings.TrimSpace(v))
+			if err != nil {
+				return nil, err
+			}
+
+			tt = append(tt, t)
+		}
+
+		filters = append(filters, inter.TypeFilter(tt...))
+	}
+
+	if operation != "" {
+		o, err := inter.ParseTransactionOperation(operation)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, inter.OperationFilter(o))
+	}
+
+	if minValue != "" {
+		v, err := strconv.ParseFloat(minValue, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum value: %w", err)
+		}
+
+		filters = append(filters, inter.MinValueFilter(float32(v)))
+	}
+
+	if maxValue != "" {
+		v, err := strconv.ParseFloat(maxValue, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maximum value: %w", err)
+		}
+
+		filters = append(filters, inter.MaxValueFilter(float32(v)))
+	}
+
+	if match != "" {
+		re, err := regexp.Compile(match)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, inter.MatchFilter(re))
+	}
+
+	return inter.MatchAll(filters...), nil
+}
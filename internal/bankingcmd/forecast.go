@@ -0,0 +1,152 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/forecast"
+	"github.com/agiacomolli/go-inter/internal/cli"
+)
+
+var (
+	days        int
+	daysUsage   = "number of days projected"
+	defaultDays = 30
+
+	scheduleFile        string
+	scheduleFileUsage   = "schedule file in YAML or JSON with the expected payments and receivables"
+	defaultScheduleFile = ""
+)
+
+func forecastCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "forecast",
+		Short: "project the daily balance from a schedule of expected payments and receivables",
+		Flags: forecastFlags,
+		Run:   withBanking(runForecast),
+	}
+}
+
+func forecastFlags(flag *flag.FlagSet) {
+	flag.IntVar(&days, "days", defaultDays, daysUsage)
+	flag.StringVar(&scheduleFile, "schedule", defaultScheduleFile, scheduleFileUsage)
+	flag.StringVar(&format, "format", format, commandFormatUsage)
+}
+
+func runForecast(ctx context.Context, banking *inter.Banking, args []string) error {
+	if days <= 0 {
+		return cli.Usagef("days must be positive")
+	}
+
+	var flows []forecast.Flow
+
+	if scheduleFile != "" {
+		var err error
+
+		flows, err = forecast.Load(scheduleFile)
+		if err != nil {
+			return fmt.Errorf("could not load schedule: %w", err)
+		}
+	}
+
+	today := time.Now()
+
+	balance, err := banking.Balance(ctx, today)
+	if err != nil {
+		return fmt.Errorf("could not get balance: %w", err)
+	}
+
+	projection := forecast.Project(balance.Available, today, days, flows)
+
+	switch format {
+	case "table":
+		writeForecastTable(balance.Available, projection)
+	case "csv", "json", "ndjson":
+		err = writeRecords(os.Stdout, format, newForecastRecords(projection))
+		if err != nil {
+			return fmt.Errorf("could not write forecast: %w", err)
+		}
+	default:
+		return cli.Usagef("invalid output format")
+	}
+
+	return nil
+}
+
+func flowDescriptions(flows []forecast.Flow) string {
+	descriptions := make([]string, 0, len(flows))
+	for _, f := range flows {
+		descriptions = append(descriptions, f.Description)
+	}
+
+	return strings.Join(descriptions, "; ")
+}
+
+// Only business days are shown, since flows are never settled on other
+// days.
+func writeForecastTable(balance float32, projection []forecast.Day) {
+	var payload strings.Builder
+	fmt.Fprintf(&payload, "Forecast from %s to %s\n\n",
+		projection[0].Date.Format(time.DateOnly),
+		projection[len(projection)-1].Date.Format(time.DateOnly))
+
+	fmt.Fprintf(&payload, "Current balance %.2f\n\n", balance)
+
+	tw := tabwriter.NewWriter(&payload, 5, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Date\t    Inflow \t   Outflow \t   Balance \tFlows")
+
+	for _, v := range projection {
+		if !v.Business {
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t%10.2f\t%10.2f\t%10.2f\t%s\t\n",
+			v.Date.Format(time.DateOnly), v.Inflow, v.Outflow, v.Balance,
+			flowDescriptions(v.Flows))
+	}
+	tw.Flush()
+
+	fmt.Print(payload.String())
+}
+
+type forecastRecord struct {
+	Date     string `json:"date"`
+	Business bool   `json:"business"`
+	Inflow   amount `json:"inflow"`
+	Outflow  amount `json:"outflow"`
+	Balance  amount `json:"balance"`
+	Flows    string `json:"flows"`
+}
+
+func newForecastRecords(projection []forecast.Day) []forecastRecord {
+	records := make([]forecastRecord, 0, len(projection))
+
+	for _, v := range projection {
+		records = append(records, forecastRecord{
+			Date:     v.Date.Format(time.DateOnly),
+			Business: v.Business,
+			Inflow:   amount(v.Inflow),
+			Outflow:  amount(v.Outflow),
+			Balance:  amount(v.Balance),
+			Flows:    flowDescriptions(v.Flows),
+		})
+	}
+
+	return records
+}
+
+func (r forecastRecord) csvHeader() []string {
+	return []string{"date", "business", "inflow", "outflow", "balance", "flows"}
+}
+
+func (r forecastRecord) csvRecord() []string {
+	return []string{r.Date, strconv.FormatBool(r.Business), r.Inflow.String(),
+		r.Outflow.String(), r.Balance.String(), r.Flows}
+}
@@ -0,0 +1,209 @@
+package bankingcmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+// Amounts are always encoded with two decimal places, so values like 0.1 do
+// not show up as 0.10000000149011612 in JSON output.
+type amount float32
+
+func (a amount) String() string {
+	return fmt.Sprintf("%.2f", float32(a))
+}
+
+func (a amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+type record interface {
+	csvHeader() []string
+	csvRecord() []string
+}
+
+type balanceRecord struct {
+	Date                    string `json:"date"`
+	Available               amount `json:"available"`
+	Limit                   amount `json:"limit"`
+	CheckOnHold             amount `json:"check_on_hold"`
+	JudiciallyBlocked       amount `json:"judicially_blocked"`
+	AdministrativelyBlocked amount `json:"administratively_blocked"`
+}
+
+func newBalanceRecord(date time.Time, b inter.Balance) balanceRecord {
+	return balanceRecord{
+		Date:                    date.Format(time.DateOnly),
+		Available:               amount(b.Available),
+		Limit:                   amount(b.Limit),
+		CheckOnHold:             amount(b.CheckOnHold),
+		JudiciallyBlocked:       amount(b.JudiciallyBlocked),
+		AdministrativelyBlocked: amount(b.AdministrativelyBlocked),
+	}
+}
+
+func (r balanceRecord) csvHeader() []string {
+	return []string{"date", "available", "limit", "check_on_hold",
+		"judicially_blocked", "administratively_blocked"}
+}
+
+func (r balanceRecord) csvRecord() []string {
+	return []string{r.Date, r.Available.String(), r.Limit.String(),
+		r.CheckOnHold.String(), r.JudiciallyBlocked.String(),
+		r.AdministrativelyBlocked.String()}
+}
+
+type transactionRecord struct {
+	ID          string `json:"id"`
+	Date        string `json:"date"`
+	Operation   string `json:"operation"`
+	Type        string `json:"type"`
+	Value       amount `json:"value"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Category    string `json:"category,omitempty"`
+}
+
+func newTransactionRecords(transactions []inter.Transaction, categorize func(inter.Transaction) string) []transactionRecord {
+	records := make([]transactionRecord, 0, len(transactions))
+
+	for i, id := range inter.TransactionIDs(transactions) {
+		v := transactions[i]
+
+		r := transactionRecord{
+			ID:          id,
+			Date:        v.Date.Format(time.DateOnly),
+			Operation:   v.Operation.String(),
+			Type:        v.Type.String(),
+			Value:       amount(v.Value),
+			Title:       v.Title,
+			Description: v.Description,
+		}
+
+		if categorize != nil {
+			r.Category = categorize(v)
+		}
+
+		records = append(records, r)
+	}
+
+	return records
+}
+
+func (r transactionRecord) csvHeader() []string {
+	return []string{"id", "date", "operation", "type", "value", "title",
+		"description", "category"}
+}
+
+func (r transactionRecord) csvRecord() []string {
+	return []string{r.ID, r.Date, r.Operation, r.Type, r.Value.String(),
+		r.Title, r.Description, r.Category}
+}
+
+type summaryRecord struct {
+	Group   string `json:"group"`
+	Count   int    `json:"count"`
+	Credits int    `json:"credits"`
+	Debits  int    `json:"debits"`
+	Inflow  amount `json:"inflow"`
+	Outflow amount `json:"outflow"`
+	Net     amount `json:"net"`
+}
+
+func newSummaryRecords(summaries []inter.Summary) []summaryRecord {
+	records := make([]summaryRecord, 0, len(summaries))
+
+	for _, v := range summaries {
+		records = append(records, summaryRecord{
+			Group:   v.Group,
+			Count:   v.Count(),
+			Credits: v.Credits,
+			Debits:  v.Debits,
+			Inflow:  amount(v.Inflow),
+			Outflow: amount(v.Outflow),
+			Net:     amount(v.Net),
+		})
+	}
+
+	return records
+}
+
+func (r summaryRecord) csvHeader() []string {
+	return []string{"group", "count", "credits", "debits", "inflow",
+		"outflow", "net"}
+}
+
+func (r summaryRecord) csvRecord() []string {
+	return []string{r.Group, fmt.Sprint(r.Count), fmt.Sprint(r.Credits),
+		fmt.Sprint(r.Debits), r.Inflow.String(), r.Outflow.String(),
+		r.Net.String()}
+}
+
+func isRecordFormat(format string) bool {
+	switch format {
+	case "csv", "json", "ndjson":
+		return true
+	}
+
+	return false
+}
+
+// Writes a single record as a JSON object, while many records are written
+// as a JSON array. CSV and NDJSON output is the same in both cases.
+func writeRecord[T record](w io.Writer, format string, r T) error {
+	if format == "json" {
+		return writeJSON(w, r)
+	}
+
+	return writeRecords(w, format, []T{r})
+}
+
+func writeRecords[T record](w io.Writer, format string, records []T) error {
+	switch format {
+	case "csv":
+		var zero T
+
+		cw := csv.NewWriter(w)
+		if err := cw.Write(zero.csvHeader()); err != nil {
+			return err
+		}
+
+		for _, r := range records {
+			if err := cw.Write(r.csvRecord()); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+
+		return cw.Error()
+	case "json":
+		return writeJSON(w, records)
+	case "ndjson":
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("invalid output format %q", format)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+
+	return enc.Encode(v)
+}
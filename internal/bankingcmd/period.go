@@ -0,0 +1,47 @@
+package bankingcmd
+
+import (
+	"flag"
+	"time"
+
+	"github.com/agiacomolli/go-inter/internal/cli"
+)
+
+var (
+	start        string
+	startUsage   = "statements start date in the format YYYY-MM-DD"
+	defaultStart = ""
+
+	end        string
+	endUsage   = "statements end date in the format YYYY-MM-DD (defaults to today)"
+	defaultEnd = ""
+)
+
+func addPeriodFlags(flag *flag.FlagSet) {
+	flag.StringVar(&start, "s", defaultStart, startUsage)
+	flag.StringVar(&start, "start-date", defaultStart, startUsage)
+	flag.StringVar(&end, "e", defaultEnd, endUsage)
+	flag.StringVar(&end, "end-date", defaultEnd, endUsage)
+}
+
+func parsePeriod() (time.Time, time.Time, error) {
+	if start == "" {
+		return time.Time{}, time.Time{}, cli.Usagef("start date is required")
+	}
+
+	startDate, err := time.Parse(time.DateOnly, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, cli.Usagef("could not parse start date: %s", err)
+	}
+
+	if end == "" {
+		return startDate, time.Now(), nil
+	}
+
+	endDate, err := time.Parse(time.DateOnly, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, cli.Usagef("could not parse end date: %s", err)
+	}
+
+	return startDate, endDate, nil
+}
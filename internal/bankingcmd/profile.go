@@ -0,0 +1,57 @@
+package bankingcmd
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/agiacomolli/go-inter/config"
+)
+
+var (
+	profile        string
+	profileUsage   = "configuration profile (defaults to the default profile of the configuration file)"
+	defaultProfile = ""
+
+	configPath        string
+	configPathUsage   = "configuration file (defaults to config.toml in the user configuration directory)"
+	defaultConfigPath = ""
+)
+
+// applyProfile sets the global flags not given on the command line from the
+// environment and the configuration profile, so flags take precedence over
+// the environment, then the profile and then the defaults.
+func applyProfile(f *flag.FlagSet) error {
+	p, err := config.Resolve(configPath, profile)
+	if err != nil {
+		return err
+	}
+
+	set := make(map[string]bool)
+	f.Visit(func(fl *flag.Flag) {
+		set[fl.Name] = true
+	})
+
+	for _, v := range []struct {
+		names  []string
+		target *string
+		value  string
+	}{
+		{[]string{"c", "cert"}, &certFile, p.Cert},
+		{[]string{"k", "key"}, &keyFile, p.Key},
+		{[]string{"key-name"}, &keyName, p.KeyName},
+		{[]string{"client-id"}, &clientID, p.ClientID},
+		{[]string{"client-secret"}, &clientSecret, p.ClientSecret},
+		{[]string{"scopes"}, &scopes, strings.Join(p.Scopes, ",")},
+		{[]string{"a", "account"}, &account, p.Account},
+		{[]string{"vault"}, &vaultPath, p.Vault},
+		{[]string{"token-cache"}, &tokenCacheDir, p.TokenCache},
+	} {
+		if v.value == "" || set[v.names[0]] || set[v.names[len(v.names)-1]] {
+			continue
+		}
+
+		*v.target = v.value
+	}
+
+	return nil
+}
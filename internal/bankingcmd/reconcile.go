@@ -0,0 +1,239 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/reconcile"
+)
+
+var (
+	ledgerFile        string
+	ledgerFileUsage   = "ledger CSV file with the expected entries"
+	defaultLedgerFile = ""
+
+	tolerance        int
+	toleranceUsage   = "maximum days between transaction and entry dates"
+	defaultTolerance = reconcile.DefaultDateTolerance
+
+	maxGroup        int
+	maxGroupUsage   = "maximum entries matched by a single transaction"
+	defaultMaxGroup = reconcile.DefaultMaxGroupSize
+
+	minSimilarity        float64
+	minSimilarityUsage   = "minimum similarity of the entry reference and description, from 0 to 1"
+	defaultMinSimilarity = 0.0
+)
+
+func reconcileCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "reconcile",
+		Short: "match statements with a ledger CSV file",
+		Flags: reconcileFlags,
+		Run:   withBanking(runReconcile),
+	}
+}
+
+func reconcileFlags(flag *flag.FlagSet) {
+	addPeriodFlags(flag)
+	flag.StringVar(&ledgerFile, "l", defaultLedgerFile, ledgerFileUsage)
+	flag.StringVar(&ledgerFile, "ledger", defaultLedgerFile, ledgerFileUsage)
+	flag.IntVar(&tolerance, "tolerance", defaultTolerance, toleranceUsage)
+	flag.IntVar(&maxGroup, "max-group", defaultMaxGroup, maxGroupUsage)
+	flag.Float64Var(&minSimilarity, "min-similarity", defaultMinSimilarity, minSimilarityUsage)
+	flag.StringVar(&format, "format", format, commandFormatUsage)
+}
+
+func runReconcile(ctx context.Context, banking *inter.Banking, args []string) error {
+	startDate, endDate, err := parsePeriod()
+	if err != nil {
+		return err
+	}
+
+	if ledgerFile == "" {
+		return cli.Usagef("ledger file is required")
+	}
+
+	f, err := os.Open(ledgerFile)
+	if err != nil {
+		return fmt.Errorf("could not open ledger: %w", err)
+	}
+
+	entries, err := reconcile.ReadCSV(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("could not read ledger: %w", err)
+	}
+
+	transactions, err := banking.Transactions(ctx, startDate, endDate)
+	if err != nil {
+		return fmt.Errorf("could not get transactions: %w", err)
+	}
+
+	result := reconcile.Reconcile(transactions, entries, reconcile.Options{
+		DateTolerance: tolerance,
+		MaxGroupSize:  maxGroup,
+		MinSimilarity: minSimilarity,
+	})
+
+	switch format {
+	case "table":
+		writeReconcileTable(startDate, endDate, result)
+	case "csv", "json", "ndjson":
+		err = writeRecords(os.Stdout, format, newReconcileRecords(result))
+		if err != nil {
+			return fmt.Errorf("could not write reconciliation: %w", err)
+		}
+	default:
+		return cli.Usagef("invalid output format")
+	}
+
+	return nil
+}
+
+func signedValue(t inter.Transaction) float32 {
+	if t.Operation == inter.DebitTransactionOperation {
+		return -t.Value
+	}
+
+	return t.Value
+}
+
+func writeReconcileTable(startDate, endDate time.Time, r reconcile.Result) {
+	var payload strings.Builder
+	fmt.Fprintf(&payload, "Reconciliation from %s to %s\n\n",
+		startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
+
+	fmt.Fprintf(&payload, "Matched (%d)\n\n", len(r.Matched))
+
+	tw := tabwriter.NewWriter(&payload, 5, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Date\t     Value \tTitle\tEntry\tEntry date\t    Amount \tReference")
+
+	for _, m := range r.Matched {
+		for i, e := range m.Entries {
+			if i == 0 {
+				fmt.Fprintf(tw, "%s\t%10.2f\t%s\t", m.Date.Format(time.DateOnly),
+					signedValue(m.Transaction), m.Title)
+			} else {
+				fmt.Fprint(tw, "\t\t\t")
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%10.2f\t%s\t\n", e.ID,
+				e.Date.Format(time.DateOnly), e.Amount, e.Reference)
+		}
+	}
+	tw.Flush()
+
+	fmt.Fprintf(&payload, "\nUnmatched bank transactions (%d)\n\n", len(r.UnmatchedBank))
+
+	tw = tabwriter.NewWriter(&payload, 5, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Date\t     Value \tTitle\tDescription")
+
+	for _, v := range r.UnmatchedBank {
+		fmt.Fprintf(tw, "%s\t%10.2f\t%s\t%s\t\n", v.Date.Format(time.DateOnly),
+			signedValue(v.Transaction), v.Title, v.Description)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(&payload, "\nUnmatched ledger entries (%d)\n\n", len(r.UnmatchedLedger))
+
+	tw = tabwriter.NewWriter(&payload, 5, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Entry\tDate\t    Amount \tReference\tDescription")
+
+	for _, e := range r.UnmatchedLedger {
+		fmt.Fprintf(tw, "%s\t%s\t%10.2f\t%s\t%s\t\n", e.ID,
+			e.Date.Format(time.DateOnly), e.Amount, e.Reference, e.Description)
+	}
+	tw.Flush()
+
+	fmt.Print(payload.String())
+}
+
+// Reconciliation records have one row per matched entry, so transactions
+// matching several entries are repeated, and one row per unmatched
+// transaction or entry.
+type reconcileRecord struct {
+	Status           string  `json:"status"`
+	TransactionID    string  `json:"transaction_id"`
+	Date             string  `json:"date"`
+	Value            *amount `json:"value"`
+	Title            string  `json:"title"`
+	EntryID          string  `json:"entry_id"`
+	EntryDate        string  `json:"entry_date"`
+	EntryAmount      *amount `json:"entry_amount"`
+	EntryReference   string  `json:"entry_reference"`
+	EntryDescription string  `json:"entry_description"`
+}
+
+func newReconcileRecords(r reconcile.Result) []reconcileRecord {
+	records := make([]reconcileRecord, 0, len(r.Matched)+len(r.UnmatchedBank)+len(r.UnmatchedLedger))
+
+	bank := func(rec *reconcileRecord, v reconcile.BankItem) {
+		value := amount(signedValue(v.Transaction))
+
+		rec.TransactionID = v.ID
+		rec.Date = v.Date.Format(time.DateOnly)
+		rec.Value = &value
+		rec.Title = v.Title
+	}
+
+	entry := func(rec *reconcileRecord, e reconcile.Entry) {
+		value := amount(e.Amount)
+
+		rec.EntryID = e.ID
+		rec.EntryDate = e.Date.Format(time.DateOnly)
+		rec.EntryAmount = &value
+		rec.EntryReference = e.Reference
+		rec.EntryDescription = e.Description
+	}
+
+	for _, m := range r.Matched {
+		for _, e := range m.Entries {
+			rec := reconcileRecord{Status: "matched"}
+			bank(&rec, m.BankItem)
+			entry(&rec, e)
+			records = append(records, rec)
+		}
+	}
+
+	for _, v := range r.UnmatchedBank {
+		rec := reconcileRecord{Status: "unmatched_bank"}
+		bank(&rec, v)
+		records = append(records, rec)
+	}
+
+	for _, e := range r.UnmatchedLedger {
+		rec := reconcileRecord{Status: "unmatched_ledger"}
+		entry(&rec, e)
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+func (r reconcileRecord) csvHeader() []string {
+	return []string{"status", "transaction_id", "date", "value", "title",
+		"entry_id", "entry_date", "entry_amount", "entry_reference",
+		"entry_description"}
+}
+
+func (r reconcileRecord) csvRecord() []string {
+	optional := func(a *amount) string {
+		if a == nil {
+			return ""
+		}
+
+		return a.String()
+	}
+
+	return []string{r.Status, r.TransactionID, r.Date, optional(r.Value),
+		r.Title, r.EntryID, r.EntryDate, optional(r.EntryAmount),
+		r.EntryReference, r.EntryDescription}
+}
@@ -0,0 +1,127 @@
+package bankingcmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/notify"
+	"github.com/agiacomolli/go-inter/tokencache"
+	"github.com/agiacomolli/go-inter/watch"
+)
+
+var (
+	execCommand        string
+	execCommandUsage   = "command run for each event, receiving the event as JSON in the standard input"
+	defaultExecCommand = ""
+
+	postURL        string
+	postURLUsage   = "URL receiving each event as a JSON POST"
+	defaultPostURL = ""
+
+	stdout        bool
+	stdoutUsage   = "write events as NDJSON to the standard output, the default when no other sink is set"
+	defaultStdout = false
+)
+
+func addSinkFlags(f *flag.FlagSet) {
+	f.StringVar(&execCommand, "exec", defaultExecCommand, execCommandUsage)
+	f.StringVar(&postURL, "post", defaultPostURL, postURLUsage)
+	f.BoolVar(&stdout, "stdout", defaultStdout, stdoutUsage)
+}
+
+// newSink returns the sinks set by the flags, writing NDJSON to the
+// standard output when no other sink is set.
+func newSink() notify.Sink {
+	var sinks []notify.Sink
+
+	if execCommand != "" {
+		fields := strings.Fields(execCommand)
+		sinks = append(sinks, notify.NewCommandSink(fields[0], fields[1:]...))
+	}
+
+	if postURL != "" {
+		sinks = append(sinks, notify.NewHTTPSink(postURL, nil))
+	}
+
+	if stdout || len(sinks) == 0 {
+		sinks = append(sinks, notify.NewWriterSink(os.Stdout))
+	}
+
+	return notify.Multi(sinks...)
+}
+
+// newConnector issues tokens from the client credentials when set, reads
+// the token cache when only the client identification is set, or uses the
+// user token otherwise.
+func newConnector(client *inter.Client, token inter.Token) (watch.Connector, error) {
+	// The client identification may be set after the command name.
+	readClientSecret()
+
+	switch {
+	case clientID != "" && clientSecret != "":
+		return func(ctx context.Context) (watch.Session, error) {
+			t, err := authorize(ctx, client)
+			if err != nil {
+				return watch.Session{}, err
+			}
+
+			// Other processes may use the new token, failing to cache
+			// it does not stop polling.
+			saveToken(t)
+
+			return newSession(inter.NewBanking(client, t), t.ExpiresAt), nil
+		}, nil
+	case clientID != "":
+		// Another process, such as inter-token, may renew the cached
+		// token.
+		return func(ctx context.Context) (watch.Session, error) {
+			t, err := cachedToken(ctx, client)
+			if errors.Is(err, tokencache.ErrNotFound) {
+				return watch.Session{}, fmt.Errorf("%w, the client secret is required to renew it: %w",
+					err, watch.ErrSessionExpired)
+			} else if err != nil {
+				return watch.Session{}, err
+			}
+
+			return newSession(inter.NewBanking(client, t), t.ExpiresAt), nil
+		}, nil
+	case token.Data != "":
+		connected := false
+
+		// A user token can not be renewed, so polling stops once the API
+		// rejects it.
+		return func(ctx context.Context) (watch.Session, error) {
+			if connected {
+				return watch.Session{}, fmt.Errorf("token rejected, client credentials are required to renew it: %w",
+					watch.ErrSessionExpired)
+			}
+
+			connected = true
+
+			return newSession(inter.NewBanking(client, token), token.ExpiresAt), nil
+		}, nil
+	default:
+		return nil, cli.Usagef("token or client credentials are required")
+	}
+}
+
+func newSession(banking *inter.Banking, expiresAt time.Time) watch.Session {
+	return watch.Session{
+		Fetcher: func(name string) watch.Fetcher {
+			// Accounts not named use the global account.
+			if name == "" {
+				name = account
+			}
+
+			return banking.WithAccount(name).WithScopeCheck()
+		},
+		ExpiresAt: expiresAt,
+	}
+}
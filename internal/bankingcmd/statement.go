@@ -0,0 +1,258 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/camt"
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/journal"
+	"github.com/agiacomolli/go-inter/ofx"
+)
+
+var (
+	journalAccount        string
+	journalAccountUsage   = "journal account of the statement transactions"
+	defaultJournalAccount = journal.DefaultAccount
+
+	incomeAccount        string
+	incomeAccountUsage   = "journal counterpart account of credits"
+	defaultIncomeAccount = journal.DefaultIncomeAccount
+
+	expenseAccount        string
+	expenseAccountUsage   = "journal counterpart account of debits"
+	defaultExpenseAccount = journal.DefaultExpenseAccount
+
+	statementFormatUsage = "overrides the global output format, also accepting 'ofx' (OFX 1.0.2 SGML), " +
+		"'ofx2' (OFX 2.2 XML), 'camt' (ISO 20022 camt.053.001.02), 'beancount' and 'ledger'"
+
+	sortKey        string
+	sortKeyUsage   = "sort key; can be 'date', 'value' or 'title', prefixed with '-' for descending order"
+	defaultSortKey = "date"
+)
+
+func statementCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "statement",
+		Short: "fetch account statements",
+		Flags: statementFlags,
+		Run:   withBanking(runStatement),
+	}
+}
+
+func statementFlags(flag *flag.FlagSet) {
+	addPeriodFlags(flag)
+	flag.StringVar(&format, "format", format, statementFormatUsage)
+	flag.StringVar(&journalAccount, "journal-account", defaultJournalAccount, journalAccountUsage)
+	flag.StringVar(&incomeAccount, "income-account", defaultIncomeAccount, incomeAccountUsage)
+	flag.StringVar(&expenseAccount, "expense-account", defaultExpenseAccount, expenseAccountUsage)
+	addFilterFlags(flag)
+	addRulesFlag(flag)
+	flag.StringVar(&sortKey, "sort", defaultSortKey, sortKeyUsage)
+}
+
+func runStatement(ctx context.Context, banking *inter.Banking, args []string) error {
+	startDate, endDate, err := parsePeriod()
+	if err != nil {
+		return err
+	}
+
+	filter, err := transactionFilter()
+	if err != nil {
+		return cli.Usagef("invalid filter: %s", err)
+	}
+
+	less, err := inter.ParseTransactionLess(sortKey)
+	if err != nil {
+		return cli.Usagef("invalid sort: %s", err)
+	}
+
+	// Exports carry the balances of the whole account, which would not add
+	// up with a subset of the transactions.
+	if isExportFormat(format) && (filtersSet() || sortKey != defaultSortKey) {
+		return cli.Usagef("filters and sorting can not be used with the %s format", format)
+	}
+
+	if (format == "ofx" || format == "ofx2" || format == "camt") && account == "" {
+		return cli.Usagef("account is required with the %s format", format)
+	}
+
+	categorize, err := transactionCategorizer()
+	if err != nil {
+		return fmt.Errorf("could not load rules: %w", err)
+	}
+
+	all, err := banking.Transactions(ctx, startDate, endDate)
+	if err != nil {
+		return fmt.Errorf("could not get transactions: %w", err)
+	}
+
+	transactions := inter.FilterTransactions(all, filter)
+	inter.SortTransactions(transactions, less)
+
+	switch format {
+	case "table":
+		closing, err := banking.Balance(ctx, endDate)
+		if err != nil {
+			return fmt.Errorf("could not get closing balance: %w", err)
+		}
+
+		opening, err := banking.Balance(ctx, startDate.AddDate(0, 0, -1))
+		if err != nil {
+			return fmt.Errorf("could not get opening balance: %w", err)
+		}
+
+		statement := inter.NewStatement(startDate, endDate, closing, all)
+		if err := statement.Check(opening); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", err)
+		}
+
+		statement.Entries = statementEntries(statement, transactions)
+		writeStatementTable(statement, categorize)
+	case "csv", "json", "ndjson":
+		err = writeRecords(os.Stdout, format, newTransactionRecords(transactions, categorize))
+		if err != nil {
+			return fmt.Errorf("could not write statement: %w", err)
+		}
+	case "ofx", "ofx2":
+		balance, err := banking.Balance(ctx, endDate)
+		if err != nil {
+			return fmt.Errorf("could not get balance: %w", err)
+		}
+
+		version := ofx.Version102
+		if format == "ofx2" {
+			version = ofx.Version220
+		}
+
+		err = ofx.Write(os.Stdout, ofx.Statement{
+			AccountID:    account,
+			Start:        startDate,
+			End:          endDate,
+			Transactions: transactions,
+			Balance:      balance,
+		}, version)
+		if err != nil {
+			return fmt.Errorf("could not write statement: %w", err)
+		}
+	case "camt":
+		opening, err := banking.Balance(ctx, startDate.AddDate(0, 0, -1))
+		if err != nil {
+			return fmt.Errorf("could not get opening balance: %w", err)
+		}
+
+		closing, err := banking.Balance(ctx, endDate)
+		if err != nil {
+			return fmt.Errorf("could not get closing balance: %w", err)
+		}
+
+		err = camt.Write(os.Stdout, camt.Statement{
+			AccountID:      account,
+			Start:          startDate,
+			End:            endDate,
+			OpeningBalance: opening,
+			ClosingBalance: closing,
+			Transactions:   transactions,
+		})
+		if err != nil {
+			return fmt.Errorf("could not write statement: %w", err)
+		}
+	case "beancount", "ledger":
+		balance, err := banking.Balance(ctx, endDate)
+		if err != nil {
+			return fmt.Errorf("could not get balance: %w", err)
+		}
+
+		write := journal.WriteBeancount
+		if format == "ledger" {
+			write = journal.WriteLedger
+		}
+
+		err = write(os.Stdout, transactions, &journal.Assertion{
+			Date:    endDate,
+			Balance: balance.Available,
+		}, journal.Options{
+			Account:        journalAccount,
+			IncomeAccount:  incomeAccount,
+			ExpenseAccount: expenseAccount,
+		})
+		if err != nil {
+			return fmt.Errorf("could not write statement: %w", err)
+		}
+	default:
+		return cli.Usagef("invalid statement format")
+	}
+
+	return nil
+}
+
+func isExportFormat(format string) bool {
+	switch format {
+	case "ofx", "ofx2", "camt", "beancount", "ledger":
+		return true
+	}
+
+	return false
+}
+
+// Running balances are computed from every transaction in the period, so the
+// filtered and sorted transactions take their balances from the statement
+// entries. Both keep the order of repeated transactions, so their
+// identifiers match.
+func statementEntries(s inter.Statement, transactions []inter.Transaction) []inter.StatementEntry {
+	all := make([]inter.Transaction, len(s.Entries))
+	for i, v := range s.Entries {
+		all[i] = v.Transaction
+	}
+
+	balances := make(map[string]float32, len(all))
+	for i, id := range inter.TransactionIDs(all) {
+		balances[id] = s.Entries[i].Balance
+	}
+
+	entries := make([]inter.StatementEntry, len(transactions))
+	for i, id := range inter.TransactionIDs(transactions) {
+		entries[i] = inter.StatementEntry{
+			Transaction: transactions[i],
+			Balance:     balances[id],
+		}
+	}
+
+	return entries
+}
+
+func writeStatementTable(s inter.Statement, categorize func(inter.Transaction) string) {
+	var payload strings.Builder
+	fmt.Fprintf(&payload, "Statements from %s to %s\n\n",
+		s.Start.Format(time.DateOnly), s.End.Format(time.DateOnly))
+
+	fmt.Fprintf(&payload, "Opening balance %.2f\n\n", s.OpeningBalance)
+
+	tw := tabwriter.NewWriter(&payload, 5, 1, 2, ' ', 0)
+	fmt.Fprint(tw, "Date\t     Value \t   Balance \tOperation\tType\t")
+	if categorize != nil {
+		fmt.Fprint(tw, "Category\t")
+	}
+	fmt.Fprintln(tw, "Title\tDescription")
+
+	for _, v := range s.Entries {
+		fmt.Fprintf(tw, "%s\t%10.2f\t%10.2f\t%s\t%s\t",
+			v.Date.Format(time.DateOnly), v.Value, v.Balance,
+			v.Operation, v.Type)
+		if categorize != nil {
+			fmt.Fprintf(tw, "%s\t", categorize(v.Transaction))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t\n", v.Title, v.Description)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(&payload, "\nClosing balance %.2f", s.ClosingBalance)
+
+	fmt.Println(payload.String())
+}
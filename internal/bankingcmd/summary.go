@@ -0,0 +1,131 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/internal/cli"
+)
+
+var (
+	groupBy        string
+	groupByUsage   = "summary grouping; can be 'day', 'week', 'month', 'type', 'operation' or 'category'"
+	defaultGroupBy = "month"
+)
+
+func summaryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "summary",
+		Short: "summarize account statements",
+		Flags: summaryFlags,
+		Run:   withBanking(runSummary),
+	}
+}
+
+func summaryFlags(flag *flag.FlagSet) {
+	addPeriodFlags(flag)
+	flag.StringVar(&groupBy, "b", defaultGroupBy, groupByUsage)
+	flag.StringVar(&groupBy, "by", defaultGroupBy, groupByUsage)
+	flag.StringVar(&format, "format", format, commandFormatUsage)
+	addFilterFlags(flag)
+	addRulesFlag(flag)
+}
+
+func runSummary(ctx context.Context, banking *inter.Banking, args []string) error {
+	startDate, endDate, err := parsePeriod()
+	if err != nil {
+		return err
+	}
+
+	filter, err := transactionFilter()
+	if err != nil {
+		return cli.Usagef("invalid filter: %s", err)
+	}
+
+	categorize, err := transactionCategorizer()
+	if err != nil {
+		return fmt.Errorf("could not load rules: %w", err)
+	}
+
+	summarize, err := summaryFunc(groupBy, categorize)
+	if err != nil {
+		return err
+	}
+
+	transactions, err := banking.Transactions(ctx, startDate, endDate)
+	if err != nil {
+		return fmt.Errorf("could not get transactions: %w", err)
+	}
+
+	transactions = inter.FilterTransactions(transactions, filter)
+
+	summaries := summarize(transactions)
+
+	switch format {
+	case "table":
+		writeSummaryTable(startDate, endDate, summaries, inter.Total(transactions))
+	case "csv", "json", "ndjson":
+		err = writeRecords(os.Stdout, format, newSummaryRecords(summaries))
+		if err != nil {
+			return fmt.Errorf("could not write summary: %w", err)
+		}
+	default:
+		return cli.Usagef("invalid output format")
+	}
+
+	return nil
+}
+
+func summaryFunc(by string, categorize func(inter.Transaction) string) (func([]inter.Transaction) []inter.Summary, error) {
+	switch by {
+	case "day":
+		return func(t []inter.Transaction) []inter.Summary {
+			return inter.SummarizeByPeriod(t, inter.DailyPeriod)
+		}, nil
+	case "week":
+		return func(t []inter.Transaction) []inter.Summary {
+			return inter.SummarizeByPeriod(t, inter.WeeklyPeriod)
+		}, nil
+	case "month":
+		return func(t []inter.Transaction) []inter.Summary {
+			return inter.SummarizeByPeriod(t, inter.MonthlyPeriod)
+		}, nil
+	case "type":
+		return inter.SummarizeByType, nil
+	case "operation":
+		return inter.SummarizeByOperation, nil
+	case "category":
+		if categorize == nil {
+			return nil, cli.Usagef("summary by category requires a rules file")
+		}
+
+		return func(t []inter.Transaction) []inter.Summary {
+			return inter.Summarize(t, categorize)
+		}, nil
+	}
+
+	return nil, cli.Usagef("invalid summary grouping %q", by)
+}
+
+func writeSummaryTable(startDate, endDate time.Time, summaries []inter.Summary, total inter.Summary) {
+	var payload strings.Builder
+	fmt.Fprintf(&payload, "Summary from %s to %s\n\n",
+		startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
+
+	tw := tabwriter.NewWriter(&payload, 5, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Group\tCredits\tDebits\t    Inflow \t   Outflow \t       Net ")
+
+	for _, v := range append(summaries, total) {
+		fmt.Fprintf(tw, "%s\t%7d\t%6d\t%10.2f\t%10.2f\t%10.2f\t\n",
+			v.Group, v.Credits, v.Debits, v.Inflow, v.Outflow, v.Net)
+	}
+	tw.Flush()
+
+	fmt.Print(payload.String())
+}
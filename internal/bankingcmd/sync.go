@@ -0,0 +1,127 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/store"
+)
+
+var (
+	storeDir        string
+	storeDirUsage   = "local store directory"
+	defaultStoreDir = defaultStorePath()
+
+	overlap        int
+	overlapUsage   = "days fetched again before the last synced date to catch late postings"
+	defaultOverlap = store.DefaultOverlapDays
+)
+
+func defaultStorePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "store"
+	}
+
+	return filepath.Join(dir, "go-inter", "store")
+}
+
+func syncCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sync",
+		Short: "store transactions and daily balances locally",
+		Flags: syncFlags,
+		Run:   withBanking(runSync),
+	}
+}
+
+func syncFlags(flag *flag.FlagSet) {
+	addPeriodFlags(flag)
+	flag.StringVar(&storeDir, "store", defaultStoreDir, storeDirUsage)
+	flag.IntVar(&overlap, "overlap", defaultOverlap, overlapUsage)
+	flag.StringVar(&format, "format", format, commandFormatUsage)
+}
+
+func runSync(ctx context.Context, banking *inter.Banking, args []string) error {
+	var opts store.SyncOptions
+
+	// Unlike other commands, the start date is only required on the first
+	// sync.
+	if start != "" {
+		startDate, err := time.Parse(time.DateOnly, start)
+		if err != nil {
+			return cli.Usagef("could not parse start date: %s", err)
+		}
+
+		opts.Start = startDate
+	}
+
+	if end != "" {
+		endDate, err := time.Parse(time.DateOnly, end)
+		if err != nil {
+			return cli.Usagef("could not parse end date: %s", err)
+		}
+
+		opts.End = endDate
+	}
+
+	opts.OverlapDays = overlap
+
+	s, err := store.Open(storeDir)
+	if err != nil {
+		return fmt.Errorf("could not open store: %w", err)
+	}
+
+	name := account
+	if name == "" {
+		name = store.DefaultAccount
+	}
+
+	result, err := s.Sync(ctx, name, banking, opts)
+	if err != nil {
+		return fmt.Errorf("could not sync: %w", err)
+	}
+
+	switch format {
+	case "table":
+		fmt.Printf("Synced %s from %s to %s, %d new transactions\n", name,
+			result.From.Format(time.DateOnly), result.To.Format(time.DateOnly),
+			result.Added)
+	case "csv", "json", "ndjson":
+		err = writeRecord(os.Stdout, format, syncRecord{
+			Account: name,
+			From:    result.From.Format(time.DateOnly),
+			To:      result.To.Format(time.DateOnly),
+			Added:   result.Added,
+		})
+		if err != nil {
+			return fmt.Errorf("could not write sync result: %w", err)
+		}
+	default:
+		return cli.Usagef("invalid output format")
+	}
+
+	return nil
+}
+
+type syncRecord struct {
+	Account string `json:"account"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Added   int    `json:"added"`
+}
+
+func (r syncRecord) csvHeader() []string {
+	return []string{"account", "from", "to", "added"}
+}
+
+func (r syncRecord) csvRecord() []string {
+	return []string{r.Account, r.From, r.To, strconv.Itoa(r.Added)}
+}
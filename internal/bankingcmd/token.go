@@ -0,0 +1,204 @@
+package bankingcmd
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/certificate"
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/tokencache"
+	"github.com/agiacomolli/go-inter/vault"
+)
+
+var (
+	clientID        string
+	clientIDUsage   = "client identification used to find and renew cached tokens"
+	defaultClientID = ""
+
+	clientSecret        string
+	clientSecretUsage   = "client secret used to renew the cached token"
+	defaultClientSecret = ""
+
+	scopes        string
+	scopesUsage   = "comma-separated client scopes of the cached token"
+	defaultScopes = "extrato.read"
+
+	tokenCacheDir        string
+	tokenCacheDirUsage   = "token cache directory"
+	defaultTokenCacheDir = defaultTokenCachePath()
+
+	vaultPath        string
+	vaultPathUsage   = "encrypted vault with the client secret, private key and cached tokens"
+	defaultVaultPath = ""
+
+	keyName        string
+	keyNameUsage   = "name of the private key in the vault, used instead of the key file"
+	defaultKeyName = ""
+
+	// secrets is the open vault, nil when the vault flag is not set.
+	secrets *vault.Vault
+)
+
+func defaultTokenCachePath() string {
+	dir, err := tokencache.DefaultDir()
+	if err != nil {
+		return "tokens"
+	}
+
+	return dir
+}
+
+// Subcommands accept the credentials after their name as well, overriding
+// the global flags.
+func addCredentialsFlags(f *flag.FlagSet) {
+	f.StringVar(&clientID, "client-id", clientID, clientIDUsage)
+	f.StringVar(&clientSecret, "client-secret", clientSecret, clientSecretUsage)
+	f.StringVar(&scopes, "scopes", scopes, scopesUsage)
+}
+
+func tokenKey() tokencache.Key {
+	return tokencache.Key{
+		ClientID: clientID,
+		Scopes:   strings.Split(scopes, ","),
+		Account:  account,
+	}
+}
+
+func authorize(ctx context.Context, client *inter.Client) (inter.Token, error) {
+	return inter.NewOAuth(client).Authorize(ctx, clientID, clientSecret,
+		strings.Split(scopes, ",")...)
+}
+
+// openVault opens the vault set by the flags, reading the client secret from
+// it when not set.
+func openVault() error {
+	if vaultPath == "" {
+		return nil
+	}
+
+	var err error
+
+	secrets, err = vault.OpenPrompt(vaultPath)
+	if err != nil {
+		return err
+	}
+
+	readClientSecret()
+
+	return nil
+}
+
+// readClientSecret sets the client secret from the vault, if it is open and
+// has the secret of the client.
+func readClientSecret() {
+	if secrets == nil || clientID == "" || clientSecret != "" {
+		return
+	}
+
+	if s, err := secrets.ClientSecret(clientID); err == nil {
+		clientSecret = s
+	}
+}
+
+// certPasswordEnv holds the password of PKCS#12 certificate files, kept out
+// of the command line.
+const certPasswordEnv = "INTER_CERT_PASSWORD"
+
+// loadCertificate reads the private key from the vault when its name is
+// set, or from the key file otherwise.
+func loadCertificate() (tls.Certificate, error) {
+	if keyName == "" {
+		return certificate.Load(certFile, keyFile, os.Getenv(certPasswordEnv))
+	}
+
+	if secrets == nil {
+		return tls.Certificate{}, errors.New("vault is required to read the private key")
+	}
+
+	return secrets.LoadX509KeyPair(certFile, keyName)
+}
+
+// cachedToken returns the cached token of the client, authorizing a new one
+// when it is not valid and the client secret is set. Tokens are cached in
+// the vault when it is open.
+func cachedToken(ctx context.Context, client *inter.Client) (inter.Token, error) {
+	var renew func(context.Context) (inter.Token, error)
+
+	if clientSecret != "" {
+		renew = func(ctx context.Context) (inter.Token, error) {
+			return authorize(ctx, client)
+		}
+	}
+
+	if secrets != nil {
+		return vaultToken(ctx, renew)
+	}
+
+	cache, err := tokencache.Open(tokenCacheDir)
+	if err != nil {
+		return inter.Token{}, err
+	}
+
+	return cache.Token(ctx, tokenKey(), renew)
+}
+
+func vaultToken(ctx context.Context, renew func(context.Context) (inter.Token, error)) (inter.Token, error) {
+	t, ok, err := secrets.Token(tokenKey().ID())
+	if err != nil {
+		return inter.Token{}, err
+	}
+
+	if ok && t.ValidFor(time.Now(), inter.TokenExpiryMargin) {
+		return t, nil
+	}
+
+	if renew == nil {
+		return inter.Token{}, tokencache.ErrNotFound
+	}
+
+	t, err = renew(ctx)
+	if err != nil {
+		return inter.Token{}, err
+	}
+
+	return t, saveToken(t)
+}
+
+// saveToken writes the token to the vault when it is open, or to the token
+// cache otherwise.
+func saveToken(t inter.Token) error {
+	if secrets != nil {
+		if err := secrets.SetToken(tokenKey().ID(), t); err != nil {
+			return err
+		}
+
+		return secrets.Save()
+	}
+
+	cache, err := tokencache.Open(tokenCacheDir)
+	if err != nil {
+		return err
+	}
+
+	return cache.Save(tokenKey(), t)
+}
+
+// userToken returns the token set by the flags, or the cached token when
+// only the client identification is set.
+func userToken(ctx context.Context, client *inter.Client) (inter.Token, error) {
+	if tokenData != "" {
+		return inter.TokenFromString(tokenData), nil
+	}
+
+	if clientID == "" {
+		return inter.Token{}, cli.Usagef("token or client identification is required")
+	}
+
+	return cachedToken(ctx, client)
+}
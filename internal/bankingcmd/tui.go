@@ -0,0 +1,94 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/internal/tui"
+	"github.com/agiacomolli/go-inter/notify"
+	"github.com/agiacomolli/go-inter/watch"
+	"golang.org/x/term"
+)
+
+var (
+	refreshInterval        time.Duration
+	refreshIntervalUsage   = "background refresh interval"
+	defaultRefreshInterval = tui.DefaultRefreshInterval
+
+	tuiAccountsUsage = "comma-separated checking accounts to show (defaults to the global account)"
+)
+
+func tuiCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "tui",
+		Short: "browse balances and statements in a full-screen interface",
+		Long: "Show the balances of the accounts and the statement of the selected one " +
+			"in a full-screen interface, refreshing them in the background. Use the " +
+			"arrows or j and k to move, left and right or [ and ] to change the month, " +
+			"t for the current month, tab to change the account, / to filter, enter " +
+			"for the transaction details, r to refresh and q to quit.",
+		Flags: tuiFlags,
+		Run:   withClient(runTUI),
+	}
+}
+
+func tuiFlags(flag *flag.FlagSet) {
+	flag.DurationVar(&refreshInterval, "i", defaultRefreshInterval, refreshIntervalUsage)
+	flag.DurationVar(&refreshInterval, "interval", defaultRefreshInterval, refreshIntervalUsage)
+	flag.StringVar(&accounts, "accounts", defaultAccounts, tuiAccountsUsage)
+	addCredentialsFlags(flag)
+}
+
+func runTUI(ctx context.Context, client *inter.Client, token inter.Token, args []string) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return cli.Usagef("tui requires a terminal")
+	}
+
+	connect, err := newConnector(client, token)
+	if err != nil {
+		return err
+	}
+
+	names := []string{account}
+	if accounts != "" {
+		names = strings.Split(accounts, ",")
+	}
+
+	// Errors are shown by the interface, not sent as events.
+	discard := notify.SinkFunc(func(ctx context.Context, e notify.Event) error {
+		return nil
+	})
+
+	sessions := watch.NewSessions(connect, 0)
+
+	// A cancelled load may still be running when the next one starts, so
+	// they share the sessions one at a time.
+	var mu sync.Mutex
+
+	load := func(ctx context.Context, start, end time.Time, update func(tui.Account)) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return sessions.ForEach(ctx, time.Now(), names, discard, func(error) {},
+			func(ctx context.Context, name string, f watch.Fetcher) error {
+				a, err := tui.Fetch(ctx, f, start, end, time.Now())
+				a.Name, a.Err = name, err
+				update(a)
+
+				return err
+			})
+	}
+
+	if err := tui.Run(ctx, os.Stdin, os.Stdout, tui.NewModel(names), load, refreshInterval); err != nil {
+		return fmt.Errorf("could not run interface: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package bankingcmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/internal/cli"
+	"github.com/agiacomolli/go-inter/watch"
+)
+
+var (
+	interval        time.Duration
+	intervalUsage   = "polling interval"
+	defaultInterval = watch.DefaultInterval
+
+	accounts        string
+	accountsUsage   = "comma-separated checking accounts to watch (defaults to the global account)"
+	defaultAccounts = ""
+
+	lookback        int
+	lookbackUsage   = "days before today fetched on every poll"
+	defaultLookback = watch.DefaultLookbackDays
+)
+
+func watchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "watch",
+		Short: "notify new transactions as they are posted",
+		Flags: watchFlags,
+		Run:   withClient(runWatch),
+	}
+}
+
+func watchFlags(flag *flag.FlagSet) {
+	flag.DurationVar(&interval, "i", defaultInterval, intervalUsage)
+	flag.DurationVar(&interval, "interval", defaultInterval, intervalUsage)
+	flag.StringVar(&accounts, "accounts", defaultAccounts, accountsUsage)
+	flag.IntVar(&lookback, "lookback", defaultLookback, lookbackUsage)
+	addSinkFlags(flag)
+	addCredentialsFlags(flag)
+}
+
+func runWatch(ctx context.Context, client *inter.Client, token inter.Token, args []string) error {
+	connect, err := newConnector(client, token)
+	if err != nil {
+		return err
+	}
+
+	opts := watch.Options{
+		Interval:     interval,
+		LookbackDays: lookback,
+		OnError: func(err error) {
+			fmt.Fprintln(os.Stderr, err)
+		},
+	}
+
+	if accounts != "" {
+		opts.Accounts = strings.Split(accounts, ",")
+	} else {
+		opts.Accounts = []string{account}
+	}
+
+	w := watch.New(connect, newSink(), opts)
+
+	if err := w.Run(ctx); err != nil {
+		return fmt.Errorf("could not watch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/tokencache"
+)
+
+// Exit codes shared by the command line tools, so scripts can tell failures
+// worth retrying from the ones needing attention.
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	// ExitUsage reports invalid commands, flags or arguments.
+	ExitUsage = 2
+	// ExitAuth reports rejected credentials, missing scopes or a missing
+	// cached token.
+	ExitAuth = 3
+	// ExitRateLimit reports the API rate limit was reached.
+	ExitRateLimit = 4
+	// ExitInvalid reports the API rejected the request, such as an invalid
+	// date range or account.
+	ExitInvalid = 5
+	// ExitNetwork reports the API could not be reached or did not answer
+	// before the context deadline.
+	ExitNetwork = 6
+)
+
+// Command is a node of a command tree. Commands with subcommands parse
+// their own flags before the subcommand name, so flags of a parent apply to
+// all of its subcommands, as in "inter banking --account 123 balance".
+type Command struct {
+	Name string
+
+	// Args describes the positional arguments in the usage line, such as
+	// "<kind> <name>".
+	Args string
+
+	// Short is the description shown in the list of commands of the parent.
+	Short string
+
+	// Long is the description shown in the command help, Short when empty.
+	Long string
+
+	// Flags registers the command flags. It is called when the command is
+	// run and when its help is generated, so flags may default to values
+	// set by the parent flags.
+	Flags func(f *flag.FlagSet)
+
+	// Before is called with the parsed flags once the command to run is
+	// known, parents first, so help requests do not depend on it. Flags
+	// given to a subcommand with the same name as a flag of the command
+	// are visited as set in its flags.
+	Before func(ctx context.Context, f *flag.FlagSet) error
+
+	// Run is called with the remaining arguments. Commands with
+	// subcommands run it when no subcommand is given.
+	Run func(ctx context.Context, args []string) error
+
+	Commands []*Command
+}
+
+// UsageError reports invalid arguments, exiting with ExitUsage and a hint
+// to the command help.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+func Usagef(format string, a ...any) error {
+	return &UsageError{Err: fmt.Errorf(format, a...)}
+}
+
+// ExitCoder is implemented by errors exiting with a specific code.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// Exit returns the exit code of the error, classifying the API errors.
+func Exit(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var u *UsageError
+	if errors.As(err, &u) {
+		return ExitUsage
+	}
+
+	var e ExitCoder
+	if errors.As(err, &e) {
+		return e.ExitCode()
+	}
+
+	// Deadlines are checked before the network errors, as
+	// context.DeadlineExceeded satisfies net.Error as well.
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ExitNetwork
+	}
+
+	var scopeErr *inter.ScopeError
+	if errors.As(err, &scopeErr) || errors.Is(err, tokencache.ErrNotFound) {
+		return ExitAuth
+	}
+
+	var apiErr *inter.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Unauthorized():
+			return ExitAuth
+		case apiErr.RateLimited():
+			return ExitRateLimit
+		case apiErr.Invalid():
+			return ExitInvalid
+		}
+
+		return ExitFailure
+	}
+
+	// Only errors from the network packages are checked, as system call
+	// errors, such as of files, satisfy net.Error too.
+	var (
+		urlErr *url.Error
+		opErr  *net.OpError
+		dnsErr *net.DNSError
+	)
+	if errors.As(err, &urlErr) || errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return ExitNetwork
+	}
+
+	return ExitFailure
+}
+
+var (
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+// Execute runs the command selected by the arguments, which do not include
+// the program name, returning the exit code.
+func Execute(ctx context.Context, root *Command, args []string) int {
+	return root.execute(ctx, []string{root.Name}, args, nil)
+}
+
+// level holds the parsed flags of each command up to the one to run.
+type level struct {
+	before func(ctx context.Context, f *flag.FlagSet) error
+	flags  *flag.FlagSet
+}
+
+func (c *Command) newFlagSet(path []string) *flag.FlagSet {
+	f := flag.NewFlagSet(strings.Join(path, " "), flag.ContinueOnError)
+	f.SetOutput(io.Discard)
+	f.Usage = func() {}
+
+	if c.Flags != nil {
+		c.Flags(f)
+	}
+
+	return f
+}
+
+func (c *Command) execute(ctx context.Context, path, args []string, levels []level) int {
+	f := c.newFlagSet(path)
+
+	if err := f.Parse(args); errors.Is(err, flag.ErrHelp) {
+		c.Help(stdout, path)
+		return ExitOK
+	} else if err != nil {
+		return report(path, &UsageError{Err: err})
+	}
+
+	levels = append(levels, level{before: c.Before, flags: f})
+
+	rest := f.Args()
+
+	if len(c.Commands) > 0 {
+		switch {
+		case len(rest) > 0 && c.Find(rest[0]) != nil:
+			sub := c.Find(rest[0])
+			return sub.execute(ctx, append(path, sub.Name), rest[1:], levels)
+		case len(rest) > 0 && c.Run == nil:
+			return report(path, Usagef("command not found: %s", rest[0]))
+		case c.Run == nil:
+			return report(path, Usagef("no command set"))
+		}
+	}
+
+	if c.Run == nil {
+		return report(path, Usagef("nothing to run"))
+	}
+
+	for i, l := range levels {
+		if l.before == nil {
+			continue
+		}
+
+		if err := l.visitOverrides(levels[i+1:]); err != nil {
+			return report(path, &UsageError{Err: err})
+		}
+
+		if err := l.before(ctx, l.flags); err != nil {
+			return report(path, err)
+		}
+	}
+
+	return report(path, c.Run(ctx, rest))
+}
+
+// visitOverrides sets the flags given to the subcommands that are bound to
+// the same variable as a flag of the level, so its Before hook sees them as
+// set and does not replace them with defaults from elsewhere, such as the
+// environment. Flags only sharing the name are left alone.
+func (l level) visitOverrides(subs []level) error {
+	var err error
+
+	for _, sub := range subs {
+		sub.flags.Visit(func(fl *flag.Flag) {
+			if err != nil {
+				return
+			}
+
+			if f := l.flags.Lookup(fl.Name); f == nil || !sameVariable(f.Value, fl.Value) {
+				return
+			}
+
+			err = l.flags.Set(fl.Name, fl.Value.String())
+		})
+	}
+
+	return err
+}
+
+// sameVariable reports whether both flag values point to the same variable,
+// as the values of the flag package do when bound with the same pointer.
+func sameVariable(a, b flag.Value) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	return va.Kind() == reflect.Pointer && va.Type() == vb.Type() &&
+		va.Pointer() == vb.Pointer()
+}
+
+// Find returns the subcommand with the name, or nil if there is none.
+func (c *Command) Find(name string) *Command {
+	for _, sub := range c.Commands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+
+	return nil
+}
+
+func report(path []string, err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	fmt.Fprintln(stderr, err)
+
+	code := Exit(err)
+	if code == ExitUsage {
+		fmt.Fprintf(stderr, "Run '%s --help' for usage.\n", strings.Join(path, " "))
+	}
+
+	return code
+}
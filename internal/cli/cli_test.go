@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/tokencache"
+	"github.com/stretchr/testify/require"
+)
+
+type exitError int
+
+func (e exitError) Error() string { return "exit error" }
+func (e exitError) ExitCode() int { return int(e) }
+
+func testTree(calls *[]string) *Command {
+	var (
+		cert string
+		date string
+	)
+
+	root := &Command{
+		Name:  "inter",
+		Short: "Inter command line",
+	}
+
+	banking := &Command{
+		Name:  "banking",
+		Short: "query the checking account",
+		Flags: func(f *flag.FlagSet) {
+			f.StringVar(&cert, "c", "cert.crt", "signed certificate file")
+			f.StringVar(&cert, "cert", "cert.crt", "signed certificate file")
+		},
+		Before: func(ctx context.Context, f *flag.FlagSet) error {
+			*calls = append(*calls, "before "+cert)
+			return nil
+		},
+		Commands: []*Command{
+			{
+				Name:  "balance",
+				Short: "get account balance",
+				Flags: func(f *flag.FlagSet) {
+					f.StringVar(&date, "date", "", "balance `date` in the format YYYY-MM-DD")
+				},
+				Run: func(ctx context.Context, args []string) error {
+					*calls = append(*calls, "balance "+date)
+					return nil
+				},
+			},
+			{
+				Name:  "fail",
+				Short: "always fail",
+				Run: func(ctx context.Context, args []string) error {
+					return exitError(4)
+				},
+			},
+		},
+	}
+
+	root.Commands = []*Command{banking, CompletionCommand(root)}
+
+	return root
+}
+
+func capture(t *testing.T) (*bytes.Buffer, *bytes.Buffer) {
+	var out, errOut bytes.Buffer
+
+	oldStdout, oldStderr := stdout, stderr
+	stdout, stderr = &out, &errOut
+	t.Cleanup(func() {
+		stdout, stderr = oldStdout, oldStderr
+	})
+
+	return &out, &errOut
+}
+
+func TestExecute(t *testing.T) {
+	var calls []string
+
+	root := testTree(&calls)
+	_, errOut := capture(t)
+
+	code := Execute(context.Background(), root, []string{"banking", "-c", "other.crt", "balance", "--date", "2022-02-02"})
+	require.Equal(t, ExitOK, code)
+	require.Equal(t, []string{"before other.crt", "balance 2022-02-02"}, calls)
+
+	require.Equal(t, ExitUsage, Execute(context.Background(), root, []string{"banking", "transfer"}))
+	require.Contains(t, errOut.String(), "command not found: transfer\nRun 'inter banking --help' for usage.")
+
+	require.Equal(t, ExitUsage, Execute(context.Background(), root, []string{"banking", "balance", "--unknown"}))
+	require.Contains(t, errOut.String(), "flag provided but not defined: -unknown")
+
+	require.Equal(t, ExitUsage, Execute(context.Background(), root, nil))
+	require.Contains(t, errOut.String(), "no command set")
+
+	require.Equal(t, 4, Execute(context.Background(), root, []string{"banking", "fail"}))
+}
+
+func TestExecuteSubcommandOverride(t *testing.T) {
+	var scopes, got string
+
+	// The parent reads the environment for flags not given, as the
+	// configuration profiles do.
+	root := &Command{
+		Name: "inter",
+		Flags: func(f *flag.FlagSet) {
+			f.StringVar(&scopes, "scopes", "", "client scopes")
+		},
+		Before: func(ctx context.Context, f *flag.FlagSet) error {
+			set := false
+			f.Visit(func(fl *flag.Flag) {
+				set = set || fl.Name == "scopes"
+			})
+
+			if v := os.Getenv("INTER_TEST_SCOPES"); v != "" && !set {
+				scopes = v
+			}
+
+			return nil
+		},
+		Commands: []*Command{
+			{
+				Name: "watch",
+				Flags: func(f *flag.FlagSet) {
+					f.StringVar(&scopes, "scopes", scopes, "client scopes")
+				},
+				Run: func(ctx context.Context, args []string) error {
+					got = scopes
+					return nil
+				},
+			},
+		},
+	}
+
+	t.Setenv("INTER_TEST_SCOPES", "extrato.read")
+
+	require.Equal(t, ExitOK, Execute(context.Background(), root, []string{"watch", "--scopes", "cob.read"}))
+	require.Equal(t, "cob.read", got)
+
+	require.Equal(t, ExitOK, Execute(context.Background(), root, []string{"--scopes", "pix.read", "watch"}))
+	require.Equal(t, "pix.read", got)
+
+	scopes = ""
+	require.Equal(t, ExitOK, Execute(context.Background(), root, []string{"watch"}))
+	require.Equal(t, "extrato.read", got)
+}
+
+func TestExecuteSubcommandSameName(t *testing.T) {
+	var profile, rules string
+
+	root := &Command{
+		Name: "inter",
+		Flags: func(f *flag.FlagSet) {
+			f.StringVar(&profile, "config", "", "profile file")
+		},
+		Before: func(ctx context.Context, f *flag.FlagSet) error {
+			set := false
+			f.Visit(func(fl *flag.Flag) {
+				set = set || fl.Name == "config"
+			})
+
+			if !set {
+				profile = "default.toml"
+			}
+
+			return nil
+		},
+		Commands: []*Command{
+			{
+				Name: "alert",
+				Flags: func(f *flag.FlagSet) {
+					f.StringVar(&rules, "config", "", "rules file")
+				},
+				Run: func(ctx context.Context, args []string) error {
+					return nil
+				},
+			},
+		},
+	}
+
+	require.Equal(t, ExitOK, Execute(context.Background(), root, []string{"alert", "--config", "alerts.yaml"}))
+	require.Equal(t, "default.toml", profile)
+	require.Equal(t, "alerts.yaml", rules)
+
+	require.Equal(t, ExitOK, Execute(context.Background(), root, []string{"--config", "work.toml", "alert", "--config", "alerts.yaml"}))
+	require.Equal(t, "work.toml", profile)
+	require.Equal(t, "alerts.yaml", rules)
+}
+
+func TestExit(t *testing.T) {
+	require.Equal(t, ExitOK, Exit(nil))
+	require.Equal(t, ExitFailure, Exit(errors.New("failed")))
+	require.Equal(t, ExitUsage, Exit(Usagef("invalid date")))
+	require.Equal(t, 5, Exit(errors.Join(errors.New("failed"), exitError(5))))
+
+	require.Equal(t, ExitAuth, Exit(fmt.Errorf("could not get token: %w", tokencache.ErrNotFound)))
+	require.Equal(t, ExitFailure, Exit(fmt.Errorf("could not open ledger: %w", &fs.PathError{Op: "open", Path: "ledger.csv", Err: syscall.ENOENT})))
+	require.Equal(t, ExitNetwork, Exit(fmt.Errorf("could not get balance: %w", context.DeadlineExceeded)))
+	require.Equal(t, ExitFailure, Exit(context.Canceled))
+	require.Equal(t, ExitAuth, Exit(&inter.ScopeError{Method: "Banking.Balance", Missing: []inter.Scope{inter.ExtratoReadScope}}))
+	require.Equal(t, ExitAuth, Exit(&inter.APIError{StatusCode: http.StatusForbidden}))
+	require.Equal(t, ExitRateLimit, Exit(&inter.APIError{StatusCode: http.StatusTooManyRequests}))
+	require.Equal(t, ExitInvalid, Exit(fmt.Errorf("could not get balance: %w", &inter.APIError{StatusCode: http.StatusBadRequest})))
+	require.Equal(t, ExitFailure, Exit(&inter.APIError{StatusCode: http.StatusInternalServerError}))
+	require.Equal(t, ExitNetwork, Exit(&url.Error{Op: "Get", URL: "https://localhost", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}))
+}
+
+func TestHelp(t *testing.T) {
+	var calls []string
+
+	root := testTree(&calls)
+	out, _ := capture(t)
+
+	require.Equal(t, ExitOK, Execute(context.Background(), root, []string{"banking", "balance", "--help"}))
+	require.Empty(t, calls)
+
+	require.Equal(t, `Usage: inter banking balance [OPTION...]
+
+get account balance
+
+Options:
+  -h, --help                 give this help list
+      --date date            balance date in the format YYYY-MM-DD
+`, out.String())
+
+	out.Reset()
+
+	require.Equal(t, ExitOK, Execute(context.Background(), root, []string{"banking", "-h"}))
+	require.Equal(t, `Usage: inter banking [OPTION...] <COMMAND>
+
+query the checking account
+
+Options:
+  -h, --help                 give this help list
+  -c, --cert string          signed certificate file (default 'cert.crt')
+
+Commands:
+  balance                    get account balance
+  fail                       always fail
+
+Run 'inter banking <COMMAND> --help' for the options of a command.
+`, out.String())
+}
+
+func TestWrap(t *testing.T) {
+	text := wrap(strings.Repeat("word ", 20), 4, 30)
+
+	for _, line := range strings.Split(text, "\n") {
+		require.LessOrEqual(t, len(line), 30)
+		require.True(t, strings.HasPrefix(line, "    word"))
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	var calls []string
+
+	root := testTree(&calls)
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		var buf bytes.Buffer
+
+		require.NoError(t, Completion(&buf, root, shell))
+		require.Contains(t, buf.String(), "balance")
+		require.Contains(t, buf.String(), "cert")
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, Completion(&buf, root, "bash"))
+	require.Contains(t, buf.String(), "\t\"\") echo \"banking completion\" ;;\n")
+	require.Contains(t, buf.String(), "\t\"banking\") echo \"--help -c --cert\" ;;\n")
+	require.Contains(t, buf.String(), "complete -F _inter inter\n")
+
+	require.Error(t, Completion(&buf, root, "powershell"))
+}
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CompletionCommand returns the command printing the completion script of
+// the root command for a shell.
+func CompletionCommand(root *Command) *Command {
+	return &Command{
+		Name:  "completion",
+		Args:  "<bash|zsh|fish>",
+		Short: "print the shell completion script",
+		Long: fmt.Sprintf("Print the completion script of %[1]s for bash, zsh or fish, such as "+
+			"'source <(%[1]s completion bash)' in ~/.bashrc or "+
+			"'%[1]s completion fish | source' in the fish configuration.", root.Name),
+		Run: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return Usagef("shell is required")
+			}
+
+			return Completion(stdout, root, args[0])
+		},
+	}
+}
+
+// Completion writes the completion script of the root command for the
+// shell, which can be bash, zsh or fish.
+func Completion(w io.Writer, root *Command, shell string) error {
+	nodes := completionNodes(root)
+
+	switch shell {
+	case "bash":
+		writeBashCompletion(w, root.Name, nodes)
+	case "zsh":
+		fmt.Fprintf(w, "#compdef %s\n\nautoload -U +X bashcompinit && bashcompinit\n\n", root.Name)
+		writeBashCompletion(w, root.Name, nodes)
+	case "fish":
+		writeFishCompletion(w, root.Name, nodes)
+	default:
+		return Usagef("unsupported shell %q", shell)
+	}
+
+	return nil
+}
+
+type completionNode struct {
+	// path holds the subcommand names after the root, separated by spaces.
+	path     string
+	commands []*Command
+	flags    []*flag.Flag
+}
+
+func completionNodes(root *Command) []completionNode {
+	var nodes []completionNode
+
+	var walk func(c *Command, path []string)
+	walk = func(c *Command, path []string) {
+		var flags []*flag.Flag
+
+		c.newFlagSet(path).VisitAll(func(f *flag.Flag) {
+			flags = append(flags, f)
+		})
+
+		nodes = append(nodes, completionNode{
+			path:     strings.Join(path[1:], " "),
+			commands: c.Commands,
+			flags:    flags,
+		})
+
+		for _, sub := range c.Commands {
+			walk(sub, append(append([]string(nil), path...), sub.Name))
+		}
+	}
+
+	walk(root, []string{root.Name})
+
+	return nodes
+}
+
+func flagName(f *flag.Flag) string {
+	if len(f.Name) == 1 {
+		return "-" + f.Name
+	}
+
+	return "--" + f.Name
+}
+
+func identifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+
+		return r
+	}, name)
+}
+
+// The bash script finds the command path from the words typed so far,
+// skipping flags, and completes its subcommands and flags, falling back to
+// file names.
+func writeBashCompletion(w io.Writer, name string, nodes []completionNode) {
+	fn := "_" + identifier(name)
+
+	fmt.Fprintf(w, "%s_commands() {\n\tcase \"$1\" in\n", fn)
+	for _, n := range nodes {
+		if len(n.commands) == 0 {
+			continue
+		}
+
+		names := make([]string, len(n.commands))
+		for i, c := range n.commands {
+			names[i] = c.Name
+		}
+
+		fmt.Fprintf(w, "\t%q) echo %q ;;\n", n.path, strings.Join(names, " "))
+	}
+	fmt.Fprintf(w, "\tesac\n}\n\n")
+
+	fmt.Fprintf(w, "%s_flags() {\n\tcase \"$1\" in\n", fn)
+	for _, n := range nodes {
+		names := []string{"--help"}
+		for _, f := range n.flags {
+			names = append(names, flagName(f))
+		}
+
+		fmt.Fprintf(w, "\t%q) echo %q ;;\n", n.path, strings.Join(names, " "))
+	}
+	fmt.Fprintf(w, "\tesac\n}\n\n")
+
+	fmt.Fprintf(w, `%[1]s() {
+	local cur="${COMP_WORDS[COMP_CWORD]}" path="" word i
+
+	for ((i = 1; i < COMP_CWORD; i++)); do
+		word="${COMP_WORDS[i]}"
+		case "$word" in -*) continue ;; esac
+
+		if [[ " $(%[1]s_commands "$path") " == *" $word "* ]]; then
+			path="${path:+$path }$word"
+		fi
+	done
+
+	if [[ "$cur" == -* ]]; then
+		COMPREPLY=($(compgen -W "$(%[1]s_flags "$path")" -- "$cur"))
+		return
+	fi
+
+	COMPREPLY=($(compgen -W "$(%[1]s_commands "$path")" -- "$cur"))
+	if [[ ${#COMPREPLY[@]} -eq 0 ]]; then
+		COMPREPLY=($(compgen -f -- "$cur"))
+	fi
+}
+
+complete -F %[1]s %[2]s
+`, fn, name)
+}
+
+func writeFishCompletion(w io.Writer, name string, nodes []completionNode) {
+	fn := "__" + identifier(name)
+
+	fmt.Fprintf(w, "function %s_commands\n\tswitch \"$argv[1]\"\n", fn)
+	for _, n := range nodes {
+		if len(n.commands) == 0 {
+			continue
+		}
+
+		names := make([]string, len(n.commands))
+		for i, c := range n.commands {
+			names[i] = fishQuote(c.Name)
+		}
+
+		fmt.Fprintf(w, "\tcase %s\n\t\tprintf '%%s\\n' %s\n", fishQuote(n.path), strings.Join(names, " "))
+	}
+	fmt.Fprintf(w, "\tend\nend\n\n")
+
+	fmt.Fprintf(w, `function %[1]s_path
+	set -l path
+	set -l words (commandline -opc)
+	set -e words[1]
+	for word in $words
+		string match -q -- '-*' $word; and continue
+		if contains -- $word (%[1]s_commands "$path")
+			set path (string join ' ' $path $word)
+		end
+	end
+	echo "$path"
+end
+
+complete -c %[2]s -e
+`, fn, name)
+
+	for _, n := range nodes {
+		cond := fmt.Sprintf("test (%s_path) = %s", fn, fishQuote(n.path))
+
+		for _, c := range n.commands {
+			fmt.Fprintf(w, "complete -c %s -n %s -f -a %s -d %s\n", name, fishQuote(cond),
+				fishQuote(c.Name), fishQuote(c.Short))
+		}
+
+		for _, f := range n.flags {
+			opt := "-l"
+			if len(f.Name) == 1 {
+				opt = "-s"
+			}
+
+			fmt.Fprintf(w, "complete -c %s -n %s %s %s -d %s\n", name, fishQuote(cond),
+				opt, f.Name, fishQuote(f.Usage))
+		}
+	}
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	helpWidth  = 80
+	helpIndent = 29
+)
+
+// Help writes the usage of the command, generated from its flags and
+// subcommands. The path holds the names of the command and its parents.
+func (c *Command) Help(w io.Writer, path []string) {
+	usage := "Usage: " + strings.Join(path, " ")
+
+	flags := c.flagHelp(path)
+	if len(flags) > 0 {
+		usage += " [OPTION...]"
+	}
+
+	if len(c.Commands) > 0 {
+		if c.Run != nil {
+			usage += " [COMMAND]"
+		} else {
+			usage += " <COMMAND>"
+		}
+	}
+
+	if c.Args != "" {
+		usage += " " + c.Args
+	}
+
+	fmt.Fprintln(w, usage)
+
+	if desc := c.description(); desc != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, wrap(desc, 0, helpWidth))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Options:")
+	writeEntry(w, "  -h, --help", "give this help list")
+
+	for _, v := range flags {
+		writeEntry(w, v.names, v.usage)
+	}
+
+	if len(c.Commands) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Commands:")
+
+		for _, sub := range c.Commands {
+			writeEntry(w, "  "+sub.Name, sub.Short)
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Run '%s <COMMAND> --help' for the options of a command.\n",
+			strings.Join(path, " "))
+	}
+}
+
+func (c *Command) description() string {
+	if c.Long != "" {
+		return c.Long
+	}
+
+	return c.Short
+}
+
+type flagHelp struct {
+	names string
+	long  []string
+	short []string
+	kind  string
+	usage string
+}
+
+// flagHelp groups the flags sharing the same variable, such as -c and
+// --cert, sorted by their longest name.
+func (c *Command) flagHelp(path []string) []flagHelp {
+	f := c.newFlagSet(path)
+
+	groups := make(map[any]*flagHelp)
+	var order []*flagHelp
+
+	f.VisitAll(func(fl *flag.Flag) {
+		var key any = fl.Name
+		if v := reflect.ValueOf(fl.Value); v.Kind() == reflect.Pointer {
+			key = v.Pointer()
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &flagHelp{}
+			groups[key] = g
+			order = append(order, g)
+
+			kind, usage := flag.UnquoteUsage(fl)
+			g.kind = kind
+
+			if !isZeroValue(fl.DefValue) {
+				usage += fmt.Sprintf(" (default '%s')", fl.DefValue)
+			}
+
+			g.usage = usage
+		}
+
+		if len(fl.Name) == 1 {
+			g.short = append(g.short, "-"+fl.Name)
+		} else {
+			g.long = append(g.long, "--"+fl.Name)
+		}
+	})
+
+	help := make([]flagHelp, 0, len(order))
+	for _, g := range order {
+		names := append(g.short, g.long...)
+
+		prefix := "  "
+		if len(g.short) == 0 {
+			prefix = "      "
+		}
+
+		g.names = prefix + strings.Join(names, ", ")
+		if g.kind != "" {
+			g.names += " " + g.kind
+		}
+		help = append(help, *g)
+	}
+
+	sort.SliceStable(help, func(i, j int) bool {
+		return help[i].key() < help[j].key()
+	})
+
+	return help
+}
+
+func (h flagHelp) key() string {
+	if len(h.long) > 0 {
+		return h.long[0][2:]
+	}
+
+	return h.short[0][1:]
+}
+
+func isZeroValue(s string) bool {
+	switch s {
+	case "", "0", "false", "0s", "[]":
+		return true
+	}
+
+	return false
+}
+
+func writeEntry(w io.Writer, names, usage string) {
+	if len(names) >= helpIndent-1 {
+		fmt.Fprintln(w, names)
+		fmt.Fprintln(w, wrap(usage, helpIndent, helpWidth))
+		return
+	}
+
+	text := wrap(usage, helpIndent, helpWidth)
+	fmt.Fprintln(w, names+text[len(names):])
+}
+
+// wrap breaks the text into lines of the width, indenting every line.
+func wrap(text string, indent, width int) string {
+	var (
+		b    strings.Builder
+		line int
+	)
+
+	pad := strings.Repeat(" ", indent)
+
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == 0:
+			b.WriteString(pad)
+			line = indent
+		case line+1+len(word) > width:
+			b.WriteString("\n" + pad)
+			line = indent
+		default:
+			b.WriteString(" ")
+			line++
+		}
+
+		b.WriteString(word)
+		line += len(word)
+	}
+
+	if line == 0 {
+		b.WriteString(pad)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,7 @@
+// Package filelock serializes access to files shared by processes, such as
+// the token cache and the vault.
+package filelock
+
+import "time"
+
+const pollInterval = 50 * time.Millisecond
@@ -0,0 +1,105 @@
+package tui
+
+import "unicode/utf8"
+
+type keyCode int
+
+const (
+	noKey keyCode = iota
+	runeKey
+	upKey
+	downKey
+	leftKey
+	rightKey
+	pageUpKey
+	pageDownKey
+	homeKey
+	endKey
+	enterKey
+	tabKey
+	backtabKey
+	backspaceKey
+	escapeKey
+	ctrlCKey
+)
+
+type key struct {
+	code keyCode
+	r    rune
+}
+
+// Escape sequences sent by the terminals for the keys, after the escape
+// and the '[' or 'O' bytes.
+var sequences = map[string]keyCode{
+	"A":  upKey,
+	"B":  downKey,
+	"C":  rightKey,
+	"D":  leftKey,
+	"H":  homeKey,
+	"F":  endKey,
+	"Z":  backtabKey,
+	"1~": homeKey,
+	"7~": homeKey,
+	"4~": endKey,
+	"8~": endKey,
+	"5~": pageUpKey,
+	"6~": pageDownKey,
+}
+
+// parseKeys decodes the keys read from a terminal in raw mode. Unknown
+// escape sequences are skipped as a whole.
+func parseKeys(b []byte) []key {
+	var keys []key
+
+	for len(b) > 0 {
+		k, n := parseKey(b)
+		if k.code != noKey {
+			keys = append(keys, k)
+		}
+
+		b = b[n:]
+	}
+
+	return keys
+}
+
+func parseKey(b []byte) (key, int) {
+	switch b[0] {
+	case 0x03:
+		return key{code: ctrlCKey}, 1
+	case '\r', '\n':
+		return key{code: enterKey}, 1
+	case '\t':
+		return key{code: tabKey}, 1
+	case 0x7f, 0x08:
+		return key{code: backspaceKey}, 1
+	case 0x1b:
+		return parseEscape(b)
+	}
+
+	if b[0] < ' ' {
+		return key{}, 1
+	}
+
+	r, n := utf8.DecodeRune(b)
+
+	return key{code: runeKey, r: r}, n
+}
+
+func parseEscape(b []byte) (key, int) {
+	if len(b) < 3 || (b[1] != '[' && b[1] != 'O') {
+		return key{code: escapeKey}, 1
+	}
+
+	// Control sequences end with a byte between '@' and '~'.
+	end := 2
+	for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+		end++
+	}
+
+	if end == len(b) {
+		return key{}, len(b)
+	}
+
+	return key{code: sequences[string(b[2:end+1])]}, end + 1
+}
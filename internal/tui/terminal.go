@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	// DefaultRefreshInterval is the interval between background refreshes.
+	DefaultRefreshInterval = 5 * time.Minute
+
+	// The terminal size is checked on this interval, as resize signals are
+	// not portable.
+	resizeInterval = 250 * time.Millisecond
+
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+var styles = map[style]string{
+	plainStyle:   "",
+	boldStyle:    "\x1b[1m",
+	reverseStyle: "\x1b[7m",
+	dimStyle:     "\x1b[2m",
+}
+
+type loaded struct {
+	generation int
+	err        error
+}
+
+// Run shows the model on the terminal until the user quits or the context
+// is done. Accounts are loaded on start, on every interval, when the period
+// changes and when asked by the user, cancelling the previous load.
+func Run(ctx context.Context, in, out *os.File, m *Model, load Loader, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return fmt.Errorf("could not set terminal raw mode: %w", err)
+	}
+	defer term.Restore(int(in.Fd()), state)
+
+	w := bufio.NewWriter(out)
+
+	fmt.Fprint(out, enterScreen)
+	defer fmt.Fprint(out, leaveScreen)
+
+	keys := make(chan []key)
+	go readKeys(in, keys)
+
+	var (
+		accounts   = make(chan Account)
+		done       = make(chan loaded)
+		generation int
+		cancel     = func() {}
+	)
+	defer func() { cancel() }()
+
+	refresh := func() {
+		cancel()
+
+		var loadCtx context.Context
+		loadCtx, cancel = context.WithCancel(ctx)
+
+		generation++
+		g := generation
+		start, end := m.Period()
+
+		m.setLoading()
+
+		go func() {
+			err := load(loadCtx, start, end, func(a Account) {
+				select {
+				case accounts <- a:
+				case <-loadCtx.Done():
+				}
+			})
+
+			select {
+			case done <- loaded{generation: g, err: err}:
+			case <-loadCtx.Done():
+			}
+		}()
+	}
+
+	refresh()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	resize := time.NewTicker(resizeInterval)
+	defer resize.Stop()
+
+	width, height := 80, 24
+
+	for {
+		if wd, ht, err := term.GetSize(int(out.Fd())); err == nil && wd > 0 && ht > 0 {
+			width, height = wd, ht
+		}
+
+		if err := draw(w, m.view(width, height)); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case ks, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			for _, k := range ks {
+				switch m.update(k) {
+				case quitAction:
+					return nil
+				case refreshAction:
+					refresh()
+				}
+			}
+		case a := <-accounts:
+			m.setAccount(a)
+		case l := <-done:
+			if l.generation == generation {
+				m.setLoaded(l.err)
+			}
+		case <-ticker.C:
+			refresh()
+		case <-resize.C:
+			if wd, ht, err := term.GetSize(int(out.Fd())); err != nil || (wd == width && ht == height) {
+				continue
+			}
+		}
+	}
+}
+
+// readKeys sends the keys read from the terminal, closing the channel when
+// it can not be read anymore.
+func readKeys(r io.Reader, keys chan<- []key) {
+	defer close(keys)
+
+	buf := make([]byte, 256)
+
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			keys <- parseKeys(buf[:n])
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// draw writes the lines from the top of the screen, clearing what is
+// below them. The terminal is in raw mode, so lines end with a carriage
+// return as well.
+func draw(w *bufio.Writer, lines []line) error {
+	w.WriteString("\x1b[H")
+
+	for i, l := range lines {
+		if i > 0 {
+			w.WriteString("\r\n")
+		}
+
+		// Lines are padded to the screen width, so the style covers the
+		// whole line.
+		if s := styles[l.style]; s != "" {
+			w.WriteString(s + l.text + "\x1b[0m")
+		} else {
+			w.WriteString(l.text)
+		}
+	}
+
+	w.WriteString("\x1b[J")
+
+	return w.Flush()
+}
@@ -0,0 +1,598 @@
+// Package tui implements the full-screen terminal interface of the banking
+// command, showing the balances of the accounts and the statement of the
+// selected one.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+// Fetcher is implemented by inter.Banking.
+type Fetcher interface {
+	Transactions(ctx context.Context, start, end time.Time) ([]inter.Transaction, error)
+	Balance(ctx context.Context, date time.Time) (inter.Balance, error)
+}
+
+// Account holds the data of an account fetched for a period.
+type Account struct {
+	Name string
+	// Balance is the current balance of the account.
+	Balance   inter.Balance
+	Statement inter.Statement
+	UpdatedAt time.Time
+	// Err is the error of the last refresh, which keeps the data fetched
+	// before it.
+	Err error
+}
+
+// Fetch returns the current balance and the statement of the period, as
+// inter.Banking.Statement does.
+func Fetch(ctx context.Context, f Fetcher, start, end, now time.Time) (Account, error) {
+	transactions, err := f.Transactions(ctx, start, end)
+	if err != nil {
+		return Account{}, err
+	}
+
+	closing, err := f.Balance(ctx, end)
+	if err != nil {
+		return Account{}, err
+	}
+
+	opening, err := f.Balance(ctx, start.AddDate(0, 0, -1))
+	if err != nil {
+		return Account{}, err
+	}
+
+	// The closing balance of a period ending today is the current one.
+	current := closing
+	if end.Before(day(now)) {
+		current, err = f.Balance(ctx, now)
+		if err != nil {
+			return Account{}, err
+		}
+	}
+
+	s := inter.NewStatement(start, end, closing, transactions)
+	_ = s.Check(opening)
+
+	return Account{
+		Balance:   current,
+		Statement: s,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Loader fetches the data of the accounts for the period, calling update
+// with each account as soon as it is fetched.
+type Loader func(ctx context.Context, start, end time.Time, update func(Account)) error
+
+type action int
+
+const (
+	noAction action = iota
+	quitAction
+	refreshAction
+)
+
+type style int
+
+const (
+	plainStyle style = iota
+	boldStyle
+	reverseStyle
+	dimStyle
+)
+
+type line struct {
+	text  string
+	style style
+}
+
+// Model is the state of the interface, updated by the keys and the fetched
+// accounts and rendered by view.
+type Model struct {
+	accounts []Account
+	selected int
+
+	start time.Time
+	end   time.Time
+
+	// entries holds the statement entries of the selected account matching
+	// the filter, with their identifiers.
+	entries []inter.StatementEntry
+	ids     []string
+
+	filter    string
+	filtering bool
+	detail    bool
+
+	cursor int
+	offset int
+	// page is the number of entries shown, set when rendering.
+	page int
+
+	loading   bool
+	err       error
+	updatedAt time.Time
+
+	now func() time.Time
+}
+
+// NewModel returns the model of the accounts showing the current month.
+func NewModel(accounts []string) *Model {
+	m := &Model{
+		accounts: make([]Account, len(accounts)),
+		page:     10,
+		now:      time.Now,
+	}
+
+	for i, name := range accounts {
+		m.accounts[i].Name = name
+	}
+
+	m.shiftPeriod(0)
+
+	return m
+}
+
+// Period returns the dates of the statement shown.
+func (m *Model) Period() (start, end time.Time) {
+	return m.start, m.end
+}
+
+func day(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+}
+
+// shiftPeriod moves the period by months, where zero shows the current
+// month. Periods are not moved past the current month and end today at
+// most.
+func (m *Model) shiftPeriod(months int) bool {
+	today := day(m.now())
+
+	start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	if months != 0 {
+		start = m.start.AddDate(0, months, 0)
+	}
+
+	if start.After(today) {
+		return false
+	}
+
+	end := start.AddDate(0, 1, -1)
+	if end.After(today) {
+		end = today
+	}
+
+	if start.Equal(m.start) && end.Equal(m.end) {
+		return false
+	}
+
+	m.start, m.end = start, end
+	m.cursor, m.offset = 0, 0
+
+	return true
+}
+
+// setAccount replaces the data of the account with the same name, keeping
+// the previous data when it failed to refresh.
+func (m *Model) setAccount(a Account) {
+	for i := range m.accounts {
+		if m.accounts[i].Name != a.Name {
+			continue
+		}
+
+		if a.Err != nil {
+			m.accounts[i].Err = a.Err
+		} else {
+			m.accounts[i] = a
+		}
+
+		if i == m.selected {
+			m.applyFilter()
+		}
+
+		return
+	}
+}
+
+func (m *Model) setLoading() {
+	m.loading = true
+}
+
+func (m *Model) setLoaded(err error) {
+	m.loading = false
+	m.err = err
+
+	if err == nil {
+		m.updatedAt = m.now()
+	}
+}
+
+func (m *Model) selectAccount(delta int) {
+	if len(m.accounts) == 0 {
+		return
+	}
+
+	m.selected = (m.selected + delta + len(m.accounts)) % len(m.accounts)
+	m.cursor, m.offset = 0, 0
+	m.applyFilter()
+}
+
+// applyFilter selects the entries of the selected account matching the
+// filter, case insensitive, in their title, description, type, operation,
+// date or value.
+func (m *Model) applyFilter() {
+	m.entries, m.ids = nil, nil
+
+	if len(m.accounts) == 0 {
+		return
+	}
+
+	entries := m.accounts[m.selected].Statement.Entries
+
+	transactions := make([]inter.Transaction, len(entries))
+	for i, e := range entries {
+		transactions[i] = e.Transaction
+	}
+
+	ids := inter.TransactionIDs(transactions)
+	filter := strings.ToLower(m.filter)
+
+	for i, e := range entries {
+		text := strings.ToLower(strings.Join([]string{
+			e.Title, e.Description, e.Type.String(), e.Operation.String(),
+			e.Date.Format(time.DateOnly), fmt.Sprintf("%.2f", e.Value),
+		}, " "))
+
+		if strings.Contains(text, filter) {
+			m.entries = append(m.entries, e)
+			m.ids = append(m.ids, ids[i])
+		}
+	}
+
+	m.moveCursor(0)
+}
+
+func (m *Model) moveCursor(delta int) {
+	m.cursor += delta
+
+	if m.cursor >= len(m.entries) {
+		m.cursor = len(m.entries) - 1
+	}
+
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// update handles a key, returning the action the terminal should take.
+func (m *Model) update(k key) action {
+	if k.code == ctrlCKey {
+		return quitAction
+	}
+
+	if m.filtering {
+		m.updateFilter(k)
+		return noAction
+	}
+
+	switch k.code {
+	case upKey:
+		m.moveCursor(-1)
+	case downKey:
+		m.moveCursor(1)
+	case pageUpKey:
+		m.moveCursor(-m.page)
+	case pageDownKey:
+		m.moveCursor(m.page)
+	case homeKey:
+		m.moveCursor(-len(m.entries))
+	case endKey:
+		m.moveCursor(len(m.entries))
+	case leftKey:
+		return m.periodAction(-1)
+	case rightKey:
+		return m.periodAction(1)
+	case tabKey:
+		m.selectAccount(1)
+	case backtabKey:
+		m.selectAccount(-1)
+	case enterKey:
+		m.detail = !m.detail
+	case escapeKey:
+		if m.detail {
+			m.detail = false
+		} else if m.filter != "" {
+			m.filter = ""
+			m.applyFilter()
+		}
+	case runeKey:
+		return m.updateRune(k.r)
+	}
+
+	return noAction
+}
+
+func (m *Model) updateRune(r rune) action {
+	switch r {
+	case 'q':
+		return quitAction
+	case 'k':
+		m.moveCursor(-1)
+	case 'j':
+		m.moveCursor(1)
+	case 'g':
+		m.moveCursor(-len(m.entries))
+	case 'G':
+		m.moveCursor(len(m.entries))
+	case 'h', '[':
+		return m.periodAction(-1)
+	case 'l', ']':
+		return m.periodAction(1)
+	case 't':
+		if m.shiftPeriod(0) {
+			return refreshAction
+		}
+	case '/':
+		m.filtering = true
+	case 'r':
+		return refreshAction
+	}
+
+	return noAction
+}
+
+func (m *Model) periodAction(months int) action {
+	if m.shiftPeriod(months) {
+		return refreshAction
+	}
+
+	return noAction
+}
+
+// updateFilter edits the filter, which is applied as it is typed.
+func (m *Model) updateFilter(k key) {
+	switch k.code {
+	case enterKey:
+		m.filtering = false
+		return
+	case escapeKey:
+		m.filtering = false
+		m.filter = ""
+	case backspaceKey:
+		if _, n := utf8.DecodeLastRuneInString(m.filter); n > 0 {
+			m.filter = m.filter[:len(m.filter)-n]
+		}
+	case runeKey:
+		m.filter += string(k.r)
+	default:
+		return
+	}
+
+	m.cursor, m.offset = 0, 0
+	m.applyFilter()
+}
+
+const detailHeight = 9
+
+// view renders the model in lines of the width, filling the height.
+func (m *Model) view(width, height int) []line {
+	lines := []line{
+		{m.titleLine(width), reverseStyle},
+		{m.accountsLine(), plainStyle},
+		{},
+	}
+
+	var (
+		account   Account
+		statement inter.Statement
+	)
+
+	if len(m.accounts) > 0 {
+		account = m.accounts[m.selected]
+		statement = account.Statement
+	}
+
+	lines = append(lines,
+		line{summaryLine(statement, m.entries), plainStyle},
+		line{},
+		line{fmt.Sprintf("%-10s  %12s  %12s  %-9s  %-13s  %s",
+			"Date", "Value", "Balance", "Operation", "Type", "Title"), boldStyle},
+	)
+
+	footer := []line{
+		{m.statusLine(account), plainStyle},
+		{"↑↓ move  ←→ period  t this month  tab account  / filter  enter details  r refresh  q quit", dimStyle},
+	}
+
+	var detail []line
+	if m.detail && m.cursor < len(m.entries) {
+		detail = m.detailLines(m.entries[m.cursor], m.ids[m.cursor])
+	}
+
+	m.page = height - len(lines) - len(detail) - len(footer)
+	if m.page < 1 {
+		m.page = 1
+	}
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+
+	if m.cursor >= m.offset+m.page {
+		m.offset = m.cursor - m.page + 1
+	}
+
+	for i := m.offset; i < m.offset+m.page; i++ {
+		if i >= len(m.entries) {
+			lines = append(lines, line{})
+			continue
+		}
+
+		s := plainStyle
+		if i == m.cursor {
+			s = reverseStyle
+		}
+
+		lines = append(lines, line{entryLine(m.entries[i]), s})
+	}
+
+	lines = append(lines, detail...)
+	lines = append(lines, footer...)
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+
+	for i := range lines {
+		lines[i].text = fit(lines[i].text, width)
+	}
+
+	return lines
+}
+
+func (m *Model) titleLine(width int) string {
+	left := fmt.Sprintf(" Inter banking  %s to %s",
+		m.start.Format(time.DateOnly), m.end.Format(time.DateOnly))
+
+	var right string
+	switch {
+	case m.loading:
+		right = "refreshing… "
+	case !m.updatedAt.IsZero():
+		right = "updated " + m.updatedAt.Format(time.TimeOnly) + " "
+	}
+
+	pad := width - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if pad < 1 {
+		pad = 1
+	}
+
+	return left + strings.Repeat(" ", pad) + right
+}
+
+// accountsLine shows the available balance of each account, the selected
+// one between brackets.
+func (m *Model) accountsLine() string {
+	parts := make([]string, len(m.accounts))
+
+	for i, a := range m.accounts {
+		name := a.Name
+		if name == "" {
+			name = "account"
+		}
+
+		var text string
+		switch {
+		case a.UpdatedAt.IsZero() && a.Err != nil:
+			text = name + " failed"
+		case a.UpdatedAt.IsZero():
+			text = name + " …"
+		default:
+			text = fmt.Sprintf("%s %.2f", name, a.Balance.Available)
+		}
+
+		if i == m.selected {
+			text = "[" + text + "]"
+		} else {
+			text = " " + text + " "
+		}
+
+		parts[i] = text
+	}
+
+	return " " + strings.Join(parts, " ")
+}
+
+func summaryLine(s inter.Statement, entries []inter.StatementEntry) string {
+	var credits, debits float64
+	for _, e := range entries {
+		if e.Operation == inter.DebitTransactionOperation {
+			debits += float64(e.Value)
+		} else {
+			credits += float64(e.Value)
+		}
+	}
+
+	text := fmt.Sprintf("Opening %.2f  Closing %.2f  Credits %.2f  Debits %.2f  %d transactions",
+		s.OpeningBalance, s.ClosingBalance, credits, debits, len(entries))
+
+	if s.Gap != 0 {
+		text += fmt.Sprintf("  Gap %.2f", s.Gap)
+	}
+
+	return text
+}
+
+func entryLine(e inter.StatementEntry) string {
+	value := e.Value
+	if e.Operation == inter.DebitTransactionOperation {
+		value = -value
+	}
+
+	return fmt.Sprintf("%-10s  %12.2f  %12.2f  %-9s  %-13s  %s",
+		e.Date.Format(time.DateOnly), value, e.Balance, e.Operation, e.Type, e.Title)
+}
+
+func (m *Model) detailLines(e inter.StatementEntry, id string) []line {
+	lines := []line{{strings.Repeat("─", 40), dimStyle}}
+
+	for _, v := range [][2]string{
+		{"Date", e.Date.Format(time.DateOnly)},
+		{"Operation", e.Operation.String()},
+		{"Type", e.Type.String()},
+		{"Value", fmt.Sprintf("%.2f", e.Value)},
+		{"Balance", fmt.Sprintf("%.2f", e.Balance)},
+		{"Title", e.Title},
+		{"Description", e.Description},
+		{"ID", id},
+	} {
+		lines = append(lines, line{fmt.Sprintf("%-12s %s", v[0], v[1]), plainStyle})
+	}
+
+	return lines
+}
+
+func (m *Model) statusLine(account Account) string {
+	switch {
+	case m.filtering:
+		return "/" + m.filter + "█"
+	case account.Err != nil:
+		return "error: " + account.Err.Error()
+	case m.err != nil:
+		return "error: " + m.err.Error()
+	case m.filter != "":
+		return fmt.Sprintf("filter: %s (%d of %d)", m.filter, len(m.entries),
+			len(account.Statement.Entries))
+	}
+
+	return ""
+}
+
+// fit pads or truncates the text to the width, counting runes.
+func fit(text string, width int) string {
+	text = strings.Map(func(r rune) rune {
+		if r < ' ' {
+			return ' '
+		}
+
+		return r
+	}, text)
+
+	n := utf8.RuneCountInString(text)
+	if n <= width {
+		return text + strings.Repeat(" ", width-n)
+	}
+
+	runes := []rune(text)
+
+	return string(runes[:width])
+}
@@ -0,0 +1,264 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher struct {
+	transactions []inter.Transaction
+	balances     map[string]float32
+	calls        []string
+}
+
+func (f *fakeFetcher) Transactions(ctx context.Context, start, end time.Time) ([]inter.Transaction, error) {
+	f.calls = append(f.calls, "transactions "+start.Format(time.DateOnly)+" "+end.Format(time.DateOnly))
+	return f.transactions, nil
+}
+
+func (f *fakeFetcher) Balance(ctx context.Context, date time.Time) (inter.Balance, error) {
+	d := date.Format(time.DateOnly)
+	f.calls = append(f.calls, "balance "+d)
+
+	return inter.Balance{Available: f.balances[d]}, nil
+}
+
+func date(s string) time.Time {
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+var testTransactions = []inter.Transaction{
+	{
+		Date:        date("2022-02-02"),
+		Type:        inter.TransferenciaTransactionType,
+		Operation:   inter.CreditTransactionOperation,
+		Value:       22373.32,
+		Title:       "Transferência recebida",
+		Description: "TED RECEBIDA - 001 BANCO 001 S.A.",
+	},
+	{
+		Date:        date("2022-02-05"),
+		Type:        inter.PixTransactionType,
+		Operation:   inter.DebitTransactionOperation,
+		Value:       22300,
+		Title:       "Pix enviado",
+		Description: "PIX ENVIADO - Cp :123456",
+	},
+	{
+		Date:        date("2022-02-09"),
+		Type:        inter.PixTransactionType,
+		Operation:   inter.DebitTransactionOperation,
+		Value:       1000,
+		Title:       "Pix enviado",
+		Description: "PIX ENVIADO - Cp :789012",
+	},
+}
+
+func testModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := NewModel([]string{"123", "456"})
+	m.now = func() time.Time { return date("2022-02-15").Add(10 * time.Hour) }
+	m.start, m.end = time.Time{}, time.Time{}
+	m.shiftPeriod(0)
+
+	f := &fakeFetcher{
+		transactions: testTransactions,
+		balances: map[string]float32{
+			"2022-01-31": 121920.25,
+			"2022-02-15": 120993.57,
+		},
+	}
+
+	start, end := m.Period()
+
+	a, err := Fetch(context.Background(), f, start, end, m.now())
+	require.NoError(t, err)
+
+	a.Name = "123"
+	m.setAccount(a)
+	m.setAccount(Account{Name: "456", Balance: inter.Balance{Available: 10}, UpdatedAt: m.now()})
+	m.setLoaded(nil)
+
+	return m
+}
+
+func TestFetch(t *testing.T) {
+	f := &fakeFetcher{
+		transactions: testTransactions,
+		balances: map[string]float32{
+			"2022-01-31": 121920.25,
+			"2022-02-28": 120993.57,
+			"2022-03-10": 100,
+		},
+	}
+
+	now := date("2022-03-10").Add(time.Hour)
+
+	a, err := Fetch(context.Background(), f, date("2022-02-01"), date("2022-02-28"), now)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"transactions 2022-02-01 2022-02-28",
+		"balance 2022-02-28",
+		"balance 2022-01-31",
+		"balance 2022-03-10",
+	}, f.calls)
+
+	require.Equal(t, float32(100), a.Balance.Available)
+	require.Equal(t, float32(121920.25), a.Statement.OpeningBalance)
+	require.Equal(t, float32(120993.57), a.Statement.ClosingBalance)
+	require.Zero(t, a.Statement.Gap)
+	require.Len(t, a.Statement.Entries, 3)
+	require.Equal(t, now, a.UpdatedAt)
+}
+
+func TestModelPeriod(t *testing.T) {
+	m := testModel(t)
+
+	start, end := m.Period()
+	require.Equal(t, date("2022-02-01"), start)
+	require.Equal(t, date("2022-02-15"), end)
+
+	require.Equal(t, noAction, m.update(key{code: rightKey}))
+
+	require.Equal(t, refreshAction, m.update(key{code: leftKey}))
+	start, end = m.Period()
+	require.Equal(t, date("2022-01-01"), start)
+	require.Equal(t, date("2022-01-31"), end)
+
+	require.Equal(t, refreshAction, m.update(key{code: runeKey, r: '['}))
+	start, _ = m.Period()
+	require.Equal(t, date("2021-12-01"), start)
+
+	require.Equal(t, refreshAction, m.update(key{code: runeKey, r: 't'}))
+	start, end = m.Period()
+	require.Equal(t, date("2022-02-01"), start)
+	require.Equal(t, date("2022-02-15"), end)
+}
+
+func TestModelNavigation(t *testing.T) {
+	m := testModel(t)
+
+	require.Len(t, m.entries, 3)
+
+	m.update(key{code: downKey})
+	m.update(key{code: runeKey, r: 'j'})
+	m.update(key{code: downKey})
+	require.Equal(t, 2, m.cursor)
+
+	m.update(key{code: homeKey})
+	require.Equal(t, 0, m.cursor)
+
+	m.update(key{code: tabKey})
+	require.Equal(t, 1, m.selected)
+	require.Empty(t, m.entries)
+
+	m.update(key{code: backtabKey})
+	require.Equal(t, 0, m.selected)
+	require.Len(t, m.entries, 3)
+
+	require.Equal(t, refreshAction, m.update(key{code: runeKey, r: 'r'}))
+	require.Equal(t, quitAction, m.update(key{code: runeKey, r: 'q'}))
+	require.Equal(t, quitAction, m.update(key{code: ctrlCKey}))
+}
+
+func TestModelFilter(t *testing.T) {
+	m := testModel(t)
+
+	m.update(key{code: runeKey, r: '/'})
+
+	for _, r := range "PIX" {
+		m.update(key{code: runeKey, r: r})
+	}
+
+	// Keys are typed into the filter while filtering.
+	require.Equal(t, noAction, m.update(key{code: runeKey, r: 'q'}))
+	m.update(key{code: backspaceKey})
+
+	require.Equal(t, "PIX", m.filter)
+	require.Len(t, m.entries, 2)
+
+	m.update(key{code: enterKey})
+	require.False(t, m.filtering)
+	require.Contains(t, m.statusLine(m.accounts[0]), "filter: PIX (2 of 3)")
+
+	m.update(key{code: escapeKey})
+	require.Empty(t, m.filter)
+	require.Len(t, m.entries, 3)
+}
+
+func TestModelRefreshError(t *testing.T) {
+	m := testModel(t)
+
+	m.setAccount(Account{Name: "123", Err: errors.New("too many requests")})
+	require.Len(t, m.entries, 3)
+	require.Equal(t, "error: too many requests", m.statusLine(m.accounts[0]))
+}
+
+func TestModelView(t *testing.T) {
+	m := testModel(t)
+
+	m.update(key{code: endKey})
+	m.update(key{code: enterKey})
+
+	lines := m.view(100, 24)
+	require.Len(t, lines, 24)
+
+	var text []string
+	for _, l := range lines {
+		require.Equal(t, 100, len([]rune(l.text)))
+		text = append(text, strings.TrimRight(l.text, " "))
+	}
+
+	require.True(t, strings.HasPrefix(text[0], " Inter banking  2022-02-01 to 2022-02-15 "))
+	require.True(t, strings.HasSuffix(text[0], " updated 10:00:00"))
+	require.Equal(t, " [123 120993.57]  456 10.00", text[1])
+	require.Equal(t, "Opening 121920.25  Closing 120993.57  Credits 22373.32  Debits 23300.00  3 transactions", text[3])
+	require.Equal(t, "2022-02-09      -1000.00     120993.57  debit      pix            Pix enviado", text[8])
+	require.Equal(t, reverseStyle, lines[8].style)
+	require.Contains(t, text, "Description  PIX ENVIADO - Cp :789012")
+
+	lines = m.view(20, 5)
+	require.Len(t, lines, 5)
+	require.Equal(t, " Inter banking  2022", lines[0].text)
+}
+
+func TestParseKeys(t *testing.T) {
+	keys := parseKeys([]byte("j\x1b[A\x1b[6~\x1bOD\x1b[3~\x1b[Zé\r\x7f\x03\x1b"))
+
+	require.Equal(t, []key{
+		{code: runeKey, r: 'j'},
+		{code: upKey},
+		{code: pageDownKey},
+		{code: leftKey},
+		{code: backtabKey},
+		{code: runeKey, r: 'é'},
+		{code: enterKey},
+		{code: backspaceKey},
+		{code: ctrlCKey},
+		{code: escapeKey},
+	}, keys)
+}
+
+func TestDraw(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := bufio.NewWriter(&buf)
+
+	require.NoError(t, draw(w, []line{{"title", reverseStyle}, {"text", plainStyle}}))
+	require.Equal(t, "\x1b[H\x1b[7mtitle\x1b[0m\r\ntext\x1b[J", buf.String())
+}
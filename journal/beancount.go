@@ -0,0 +1,46 @@
+package journal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+// WriteBeancount writes the transactions as a Beancount journal. Beancount
+// checks balance assertions at the beginning of the day, so the assertion
+// is dated on the day after the given date.
+func WriteBeancount(w io.Writer, transactions []inter.Transaction, assertion *Assertion, opts Options) error {
+	opts = opts.withDefaults()
+
+	var b strings.Builder
+
+	for i, id := range inter.TransactionIDs(transactions) {
+		t := transactions[i]
+
+		fmt.Fprintf(&b, "%s * %s %s\n", t.Date.Format(time.DateOnly),
+			beancountString(opts.Payee(t)), beancountString(t.Description))
+		fmt.Fprintf(&b, "  inter-id: %s\n", beancountString(id))
+		fmt.Fprintf(&b, "  inter-type: %s\n", beancountString(t.Type.String()))
+		fmt.Fprintf(&b, "  %s  %.2f %s\n", opts.Account, signedValue(t), opts.Currency)
+		fmt.Fprintf(&b, "  %s\n\n", opts.counterAccount(t))
+	}
+
+	if assertion != nil {
+		fmt.Fprintf(&b, "%s balance %s  %.2f %s\n",
+			assertion.Date.AddDate(0, 0, 1).Format(time.DateOnly),
+			opts.Account, assertion.Balance, opts.Currency)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+var beancountEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func beancountString(s string) string {
+	return `"` + beancountEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,101 @@
+package journal
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+const (
+	DefaultAccount        = "Assets:Bank:Inter"
+	DefaultIncomeAccount  = "Income:Uncategorized"
+	DefaultExpenseAccount = "Expenses:Uncategorized"
+	DefaultCurrency       = "BRL"
+)
+
+type Options struct {
+	// Account receiving the statement transactions.
+	Account string
+	// Counterpart account of credit transactions.
+	IncomeAccount string
+	// Counterpart account of debit transactions.
+	ExpenseAccount string
+	Currency       string
+
+	// Payee extracts the payee of a transaction, defaults to DefaultPayee.
+	Payee func(inter.Transaction) string
+}
+
+func (o Options) withDefaults() Options {
+	if o.Account == "" {
+		o.Account = DefaultAccount
+	}
+
+	if o.IncomeAccount == "" {
+		o.IncomeAccount = DefaultIncomeAccount
+	}
+
+	if o.ExpenseAccount == "" {
+		o.ExpenseAccount = DefaultExpenseAccount
+	}
+
+	if o.Currency == "" {
+		o.Currency = DefaultCurrency
+	}
+
+	if o.Payee == nil {
+		o.Payee = DefaultPayee
+	}
+
+	return o
+}
+
+func (o Options) counterAccount(t inter.Transaction) string {
+	if t.Operation == inter.DebitTransactionOperation {
+		return o.ExpenseAccount
+	}
+
+	return o.IncomeAccount
+}
+
+// Assertion is the account balance at the end of the given date.
+type Assertion struct {
+	Date    time.Time
+	Balance float32
+}
+
+// Pix and TED descriptions look like "PIX RECEBIDO - Cp :12345678-FULANO DE
+// TAL" or "TED RECEBIDA - 001 BANCO 001 S.A.", the counterpart coming after
+// the account code or the last dash.
+var (
+	counterpartCodeRegexp = regexp.MustCompile(`(?i)cp\s*:\s*[0-9]+\s*-\s*(.+)$`)
+	counterpartRegexp     = regexp.MustCompile(`\s-\s+(.+)$`)
+)
+
+// DefaultPayee extracts the counterpart name from the transaction
+// description, falling back to the transaction title.
+func DefaultPayee(t inter.Transaction) string {
+	for _, re := range []*regexp.Regexp{counterpartCodeRegexp, counterpartRegexp} {
+		m := re.FindStringSubmatch(t.Description)
+		if m == nil {
+			continue
+		}
+
+		payee := strings.TrimSpace(m[1])
+		if payee != "" && !strings.HasPrefix(strings.ToLower(payee), "cp :") {
+			return payee
+		}
+	}
+
+	return t.Title
+}
+
+func signedValue(t inter.Transaction) float32 {
+	if t.Operation == inter.DebitTransactionOperation {
+		return -t.Value
+	}
+
+	return t.Value
+}
@@ -0,0 +1,132 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+var testDate = time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)
+
+func testTransactions() []inter.Transaction {
+	return []inter.Transaction{
+		{
+			Date:        testDate,
+			Type:        inter.PixTransactionType,
+			Operation:   inter.CreditTransactionOperation,
+			Value:       150.5,
+			Title:       "Pix recebido",
+			Description: `PIX RECEBIDO - Cp :12345678-FULANO "DE" TAL`,
+		},
+		{
+			Date:        testDate.AddDate(0, 0, 1),
+			Type:        inter.PixTransactionType,
+			Operation:   inter.DebitTransactionOperation,
+			Value:       20,
+			Title:       "Pix enviado",
+			Description: "PIX ENVIADO - Cp :123456",
+		},
+	}
+}
+
+func TestDefaultPayee(t *testing.T) {
+	t.Run("extracts the counterpart after the account code", func(t *testing.T) {
+		payee := DefaultPayee(inter.Transaction{
+			Title:       "Pix recebido",
+			Description: "PIX RECEBIDO - Cp :12345678-FULANO DE TAL",
+		})
+		require.Equal(t, payee, "FULANO DE TAL")
+	})
+
+	t.Run("extracts the counterpart after the last dash", func(t *testing.T) {
+		payee := DefaultPayee(inter.Transaction{
+			Title:       "Transferência recebida",
+			Description: "TED RECEBIDA - 001 BANCO 001 S.A.",
+		})
+		require.Equal(t, payee, "001 BANCO 001 S.A.")
+	})
+
+	t.Run("falls back to the title", func(t *testing.T) {
+		payee := DefaultPayee(inter.Transaction{
+			Title:       "Pix enviado",
+			Description: "PIX ENVIADO - Cp :123456",
+		})
+		require.Equal(t, payee, "Pix enviado")
+	})
+}
+
+func TestWriteBeancount(t *testing.T) {
+	t.Run("writes transactions and the balance assertion", func(t *testing.T) {
+		transactions := testTransactions()
+		ids := inter.TransactionIDs(transactions)
+
+		var b strings.Builder
+
+		err := WriteBeancount(&b, transactions, &Assertion{
+			Date:    testDate.AddDate(0, 0, 1),
+			Balance: 1130.5,
+		}, Options{Account: "Assets:Inter", ExpenseAccount: "Expenses:Pix"})
+		require.NoError(t, err)
+
+		want := `2022-02-02 * "FULANO \"DE\" TAL" "PIX RECEBIDO - Cp :12345678-FULANO \"DE\" TAL"
+  inter-id: "` + ids[0] + `"
+  inter-type: "pix"
+  Assets:Inter  150.50 BRL
+  Income:Uncategorized
+
+2022-02-03 * "Pix enviado" "PIX ENVIADO - Cp :123456"
+  inter-id: "` + ids[1] + `"
+  inter-type: "pix"
+  Assets:Inter  -20.00 BRL
+  Expenses:Pix
+
+2022-02-04 balance Assets:Inter  1130.50 BRL
+`
+		require.Equal(t, b.String(), want)
+	})
+}
+
+func TestWriteLedger(t *testing.T) {
+	t.Run("writes transactions and the balance assertion", func(t *testing.T) {
+		transactions := testTransactions()
+		ids := inter.TransactionIDs(transactions)
+
+		var b strings.Builder
+
+		err := WriteLedger(&b, transactions, &Assertion{
+			Date:    testDate.AddDate(0, 0, 1),
+			Balance: 1130.5,
+		}, Options{Payee: func(t inter.Transaction) string { return t.Title }})
+		require.NoError(t, err)
+
+		want := `2022-02-02 * Pix recebido
+    ; PIX RECEBIDO - Cp :12345678-FULANO "DE" TAL
+    ; inter-id: ` + ids[0] + `
+    ; inter-type: pix
+    Assets:Bank:Inter  150.50 BRL
+    Income:Uncategorized
+
+2022-02-03 * Pix enviado
+    ; PIX ENVIADO - Cp :123456
+    ; inter-id: ` + ids[1] + `
+    ; inter-type: pix
+    Assets:Bank:Inter  -20.00 BRL
+    Expenses:Uncategorized
+
+2022-02-03 * Balance assertion
+    Assets:Bank:Inter  0 BRL = 1130.50 BRL
+`
+		require.Equal(t, b.String(), want)
+	})
+
+	t.Run("omits the assertion", func(t *testing.T) {
+		var b strings.Builder
+
+		err := WriteLedger(&b, nil, nil, Options{})
+		require.NoError(t, err)
+		require.Empty(t, b.String())
+	})
+}
@@ -0,0 +1,49 @@
+package journal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+// WriteLedger writes the transactions as a ledger-cli journal, with the
+// balance assertion as an empty transaction at the end of the given date.
+func WriteLedger(w io.Writer, transactions []inter.Transaction, assertion *Assertion, opts Options) error {
+	opts = opts.withDefaults()
+
+	var b strings.Builder
+
+	for i, id := range inter.TransactionIDs(transactions) {
+		t := transactions[i]
+
+		fmt.Fprintf(&b, "%s * %s\n", t.Date.Format(time.DateOnly),
+			ledgerString(opts.Payee(t)))
+
+		if t.Description != "" {
+			fmt.Fprintf(&b, "    ; %s\n", ledgerString(t.Description))
+		}
+
+		fmt.Fprintf(&b, "    ; inter-id: %s\n", id)
+		fmt.Fprintf(&b, "    ; inter-type: %s\n", t.Type)
+		fmt.Fprintf(&b, "    %s  %.2f %s\n", opts.Account, signedValue(t), opts.Currency)
+		fmt.Fprintf(&b, "    %s\n\n", opts.counterAccount(t))
+	}
+
+	if assertion != nil {
+		fmt.Fprintf(&b, "%s * Balance assertion\n", assertion.Date.Format(time.DateOnly))
+		fmt.Fprintf(&b, "    %s  0 %s = %.2f %s\n", opts.Account, opts.Currency,
+			assertion.Balance, opts.Currency)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// Payees and comments are single line.
+func ledgerString(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
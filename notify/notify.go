@@ -0,0 +1,173 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+const (
+	TransactionEvent = "transaction"
+	AlertEvent       = "alert"
+	ResolvedEvent    = "resolved"
+	ErrorEvent       = "error"
+)
+
+// Event is sent to the sinks as JSON. Transaction is set on transaction
+// events, Alert on alert and resolved events and Message on alert, resolved
+// and error events.
+type Event struct {
+	Type    string    `json:"type"`
+	Account string    `json:"account,omitempty"`
+	Time    time.Time `json:"time"`
+
+	Transaction *Transaction `json:"transaction,omitempty"`
+	Alert       *Alert       `json:"alert,omitempty"`
+	Message     string       `json:"message,omitempty"`
+}
+
+type Alert struct {
+	Rule      string `json:"rule"`
+	Field     string `json:"field"`
+	Condition string `json:"condition"`
+	Threshold string `json:"threshold"`
+	Value     string `json:"value"`
+}
+
+type Transaction struct {
+	ID          string `json:"id"`
+	Date        string `json:"date"`
+	Operation   string `json:"operation"`
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func NewTransaction(id string, t inter.Transaction) *Transaction {
+	return &Transaction{
+		ID:          id,
+		Date:        t.Date.Format(time.DateOnly),
+		Operation:   t.Operation.String(),
+		Type:        t.Type.String(),
+		Value:       fmt.Sprintf("%.2f", t.Value),
+		Title:       t.Title,
+		Description: t.Description,
+	}
+}
+
+// Sink delivers events, being safe for concurrent use.
+type Sink interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+type SinkFunc func(ctx context.Context, e Event) error
+
+func (f SinkFunc) Notify(ctx context.Context, e Event) error {
+	return f(ctx, e)
+}
+
+// Multi notifies every sink, returning all errors joined.
+func Multi(sinks ...Sink) Sink {
+	return SinkFunc(func(ctx context.Context, e Event) error {
+		var errs []error
+
+		for _, s := range sinks {
+			if err := s.Notify(ctx, e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// NewWriterSink writes each event as a JSON line.
+func NewWriterSink(w io.Writer) Sink {
+	var mu sync.Mutex
+
+	return SinkFunc(func(ctx context.Context, e Event) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		_, err = w.Write(append(data, '\n'))
+
+		return err
+	})
+}
+
+// NewCommandSink runs the command for each event, with the event as JSON in
+// the standard input and its type in the INTER_EVENT environment variable.
+func NewCommandSink(name string, args ...string) Sink {
+	return SinkFunc(func(ctx context.Context, e Event) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(),
+			"INTER_EVENT="+e.Type,
+			"INTER_ACCOUNT="+e.Account)
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("could not run %s: %w", name, err)
+		}
+
+		return nil
+	})
+}
+
+// NewHTTPSink posts each event as JSON to the given URL, using
+// http.DefaultClient when client is nil.
+func NewHTTPSink(url string, client *http.Client) Sink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return SinkFunc(func(ctx context.Context, e Event) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+
+		req.Header.Add("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("could not post event to %s: %s", url, resp.Status)
+		}
+
+		return nil
+	})
+}
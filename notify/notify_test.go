@@ -0,0 +1,148 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = Event{
+	Type:    TransactionEvent,
+	Account: "12345",
+	Time:    time.Date(2022, time.February, 2, 10, 0, 0, 0, time.UTC),
+	Transaction: NewTransaction("abc", inter.Transaction{
+		Date:        time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC),
+		Type:        inter.PixTransactionType,
+		Operation:   inter.CreditTransactionOperation,
+		Value:       1432.57,
+		Title:       "Pix recebido",
+		Description: "PIX RECEBIDO - Cp :12345678-Fulano",
+	}),
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := NewWriterSink(&buf)
+
+	require.NoError(t, s.Notify(context.Background(), testEvent))
+	require.NoError(t, s.Notify(context.Background(), Event{Type: ErrorEvent, Message: "failed"}))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	require.JSONEq(t, `{
+		"type": "transaction",
+		"account": "12345",
+		"time": "2022-02-02T10:00:00Z",
+		"transaction": {
+			"id": "abc",
+			"date": "2022-02-02",
+			"operation": "credit",
+			"type": "pix",
+			"value": "1432.57",
+			"title": "Pix recebido",
+			"description": "PIX RECEBIDO - Cp :12345678-Fulano"
+		}
+	}`, string(lines[0]))
+
+	require.JSONEq(t, `{"type": "error", "time": "0001-01-01T00:00:00Z", "message": "failed"}`,
+		string(lines[1]))
+}
+
+func TestCommandSink(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+
+	out := filepath.Join(t.TempDir(), "event.json")
+
+	t.Run("passes the event in the standard input", func(t *testing.T) {
+		s := NewCommandSink("sh", "-c", `cat > "$0"; echo >> "$0"; echo "$INTER_EVENT $INTER_ACCOUNT" >> "$0"`, out)
+
+		require.NoError(t, s.Notify(context.Background(), testEvent))
+
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+
+		lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+		require.Len(t, lines, 2)
+
+		var e Event
+		require.NoError(t, json.Unmarshal(lines[0], &e))
+		require.Equal(t, testEvent, e)
+		require.Equal(t, "transaction 12345", string(lines[1]))
+	})
+
+	t.Run("returns an error when the command fails", func(t *testing.T) {
+		s := NewCommandSink("sh", "-c", "exit 1")
+
+		require.Error(t, s.Notify(context.Background(), testEvent))
+	})
+}
+
+func TestHTTPSink(t *testing.T) {
+	t.Run("posts the event", func(t *testing.T) {
+		var received Event
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "POST", r.Method)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &received))
+		}))
+		defer ts.Close()
+
+		s := NewHTTPSink(ts.URL, nil)
+
+		require.NoError(t, s.Notify(context.Background(), testEvent))
+		require.Equal(t, testEvent, received)
+	})
+
+	t.Run("returns an error on unexpected status", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		s := NewHTTPSink(ts.URL, ts.Client())
+
+		require.Error(t, s.Notify(context.Background(), testEvent))
+	})
+}
+
+func TestMulti(t *testing.T) {
+	var a, b []Event
+
+	failure := errors.New("failure")
+
+	s := Multi(
+		SinkFunc(func(ctx context.Context, e Event) error {
+			a = append(a, e)
+			return failure
+		}),
+		SinkFunc(func(ctx context.Context, e Event) error {
+			b = append(b, e)
+			return nil
+		}),
+	)
+
+	err := s.Notify(context.Background(), testEvent)
+	require.ErrorIs(t, err, failure)
+	require.Equal(t, []Event{testEvent}, a)
+	require.Equal(t, []Event{testEvent}, b)
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -52,12 +51,13 @@ func (o *OAuth) Authorize(ctx context.Context, clientID, clientSecret string, sc
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		e, err := parseApiOAuthResponseError(data)
-		if err != nil {
-			return Token{}, err
+		// The error title describes the failure better than the raw
+		// body, which is kept when it is not a JSON error.
+		if e, err := parseApiOAuthResponseError(data); err == nil && e.ErrorTitle != "" {
+			data = []byte(e.ErrorTitle)
 		}
 
-		return Token{}, errors.New(e.ErrorTitle)
+		return Token{}, newApiError(resp, data)
 	}
 
 	return parseApiResponseToken(data)
@@ -97,7 +97,7 @@ func parseApiResponseToken(d []byte) (Token, error) {
 	return Token{
 		Data:      tmp.Data,
 		Type:      tmp.Type,
-		Scopes:    strings.Split(tmp.Scopes, " "),
+		Scopes:    strings.Fields(tmp.Scopes),
 		ExpiresAt: time.Now().Add(time.Duration(tmp.ExpiresIn) * time.Second),
 	}, nil
 }
@@ -41,6 +41,22 @@ func TestApiOauthResponseError(t *testing.T) {
 	})
 }
 
+func TestApiResponseToken(t *testing.T) {
+	t.Run("splits the scopes", func(t *testing.T) {
+		token, err := parseApiResponseToken([]byte(`{"access_token": "token", "scope": "extrato.read  boleto-cobranca.read"}`))
+		require.NoError(t, err)
+		require.Equal(t, []string{"extrato.read", "boleto-cobranca.read"}, token.Scopes)
+	})
+
+	t.Run("has no scopes when the response omits them", func(t *testing.T) {
+		for _, data := range []string{`{"access_token": "token", "scope": ""}`, `{"access_token": "token"}`} {
+			token, err := parseApiResponseToken([]byte(data))
+			require.NoError(t, err)
+			require.Empty(t, token.Scopes, data)
+		}
+	})
+}
+
 func TestOAuthAutorize(t *testing.T) {
 	t.Run("returns an error on context cancelation", func(t *testing.T) {
 		client := NewClient(tls.Certificate{})
@@ -0,0 +1,49 @@
+package ofx
+
+import (
+	"strings"
+)
+
+type element struct {
+	name     string
+	value    string
+	children []element
+}
+
+func node(name string, children ...element) element {
+	return element{name: name, children: children}
+}
+
+func leaf(name, value string) element {
+	return element{name: name, value: value}
+}
+
+// SGML documents omit the closing tag of leaf elements, while XML documents
+// close every element.
+func (e element) render(b *strings.Builder, depth int, xml bool) {
+	indent := strings.Repeat("\t", depth)
+
+	if e.children == nil {
+		b.WriteString(indent + "<" + e.name + ">" + escape(e.value))
+		if xml {
+			b.WriteString("</" + e.name + ">")
+		}
+		b.WriteString("\n")
+
+		return
+	}
+
+	b.WriteString(indent + "<" + e.name + ">\n")
+
+	for _, c := range e.children {
+		c.render(b, depth+1, xml)
+	}
+
+	b.WriteString(indent + "</" + e.name + ">\n")
+}
+
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
@@ -0,0 +1,208 @@
+package ofx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+const (
+	// Banco Inter code in the brazilian payment system.
+	DefaultBankID   = "077"
+	DefaultCurrency = "BRL"
+)
+
+// ErrAccountRequired is returned for statements without the account
+// identification, which importers need to know the account of the
+// transactions.
+var ErrAccountRequired = errors.New("account identification is required")
+
+type Version int
+
+const (
+	// OFX 1.0.2, using SGML without closing tags for leaf elements.
+	Version102 = Version(102)
+	// OFX 2.2.0, using XML.
+	Version220 = Version(220)
+)
+
+type Statement struct {
+	BankID    string
+	AccountID string
+	Currency  string
+
+	Start time.Time
+	End   time.Time
+
+	Transactions []inter.Transaction
+
+	Balance     inter.Balance
+	BalanceDate time.Time
+}
+
+// Write renders the statement in the given OFX version. Empty bank
+// identification and currency fall back to Banco Inter and BRL, while the
+// account identification is required. The ledger balance is the booked
+// balance and the available balance the one available to spend.
+func Write(w io.Writer, s Statement, version Version) error {
+	if s.AccountID == "" {
+		return ErrAccountRequired
+	}
+
+	var header string
+
+	switch version {
+	case Version102:
+		header = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:UNICODE
+CHARSET:NONE
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+`
+	case Version220:
+		header = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+`
+	default:
+		return fmt.Errorf("unsupported ofx version %d", version)
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	statementDocument(s, time.Now()).render(&b, 0, version == Version220)
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func statementDocument(s Statement, now time.Time) element {
+	bankID := s.BankID
+	if bankID == "" {
+		bankID = DefaultBankID
+	}
+
+	currency := s.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	balanceDate := s.BalanceDate
+	if balanceDate.IsZero() {
+		balanceDate = s.End
+	}
+
+	transactions := make([]element, 0, len(s.Transactions)+2)
+	transactions = append(transactions,
+		leaf("DTSTART", formatDate(s.Start)),
+		leaf("DTEND", formatDate(s.End)))
+
+	for i, id := range inter.TransactionIDs(s.Transactions) {
+		transactions = append(transactions, transactionElement(s.Transactions[i], id))
+	}
+
+	status := node("STATUS",
+		leaf("CODE", "0"),
+		leaf("SEVERITY", "INFO"))
+
+	return node("OFX",
+		node("SIGNONMSGSRSV1",
+			node("SONRS",
+				status,
+				leaf("DTSERVER", formatDateTime(now)),
+				leaf("LANGUAGE", "POR"))),
+		node("BANKMSGSRSV1",
+			node("STMTTRNRS",
+				leaf("TRNUID", "1"),
+				status,
+				node("STMTRS",
+					leaf("CURDEF", currency),
+					node("BANKACCTFROM",
+						leaf("BANKID", bankID),
+						leaf("ACCTID", s.AccountID),
+						leaf("ACCTTYPE", "CHECKING")),
+					node("BANKTRANLIST", transactions...),
+					node("LEDGERBAL",
+						leaf("BALAMT", formatAmount(s.Balance.Booked())),
+						leaf("DTASOF", formatDate(balanceDate))),
+					node("AVAILBAL",
+						leaf("BALAMT", formatAmount(s.Balance.Available)),
+						leaf("DTASOF", formatDate(balanceDate)))))))
+}
+
+func transactionElement(t inter.Transaction, id string) element {
+	amount := t.Value
+	if t.Operation == inter.DebitTransactionOperation {
+		amount = -amount
+	}
+
+	children := []element{
+		leaf("TRNTYPE", transactionType(t)),
+		leaf("DTPOSTED", formatDate(t.Date)),
+		leaf("TRNAMT", formatAmount(amount)),
+		leaf("FITID", id),
+	}
+
+	if t.Title != "" {
+		children = append(children, leaf("NAME", truncate(t.Title, 32)))
+	}
+
+	if t.Description != "" {
+		children = append(children, leaf("MEMO", truncate(t.Description, 255)))
+	}
+
+	return node("STMTTRN", children...)
+}
+
+func transactionType(t inter.Transaction) string {
+	switch t.Type {
+	case inter.PixTransactionType, inter.TransferenciaTransactionType:
+		return "XFER"
+	case inter.PagamentoTransactionType:
+		if t.Operation == inter.DebitTransactionOperation {
+			return "PAYMENT"
+		}
+	}
+
+	switch t.Operation {
+	case inter.CreditTransactionOperation:
+		return "CREDIT"
+	case inter.DebitTransactionOperation:
+		return "DEBIT"
+	}
+
+	return "OTHER"
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+func formatDateTime(t time.Time) string {
+	_, offset := t.Zone()
+
+	return fmt.Sprintf("%s[%d:%s]", t.Format("20060102150405"), offset/3600,
+		t.Format("MST"))
+}
+
+func formatAmount(v float32) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n])
+}
@@ -0,0 +1,120 @@
+package ofx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+func testStatement() Statement {
+	date := time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)
+
+	return Statement{
+		AccountID: "12345",
+		Start:     date,
+		End:       date.AddDate(0, 0, 10),
+		Transactions: []inter.Transaction{
+			{
+				Date:        date,
+				Type:        inter.TransferenciaTransactionType,
+				Operation:   inter.CreditTransactionOperation,
+				Value:       22373.32,
+				Title:       "Transferência recebida",
+				Description: "TED RECEBIDA - 001 BANCO 001 S.A.",
+			},
+			{
+				Date:        date.AddDate(0, 0, 3),
+				Type:        inter.PagamentoTransactionType,
+				Operation:   inter.DebitTransactionOperation,
+				Value:       100,
+				Title:       "Pagamento efetuado",
+				Description: "Boleto <energia> & água",
+			},
+			{
+				Date:      date.AddDate(0, 0, 3),
+				Operation: inter.DebitTransactionOperation,
+				Value:     1.5,
+			},
+		},
+		Balance: inter.Balance{Available: 1432.57, CheckOnHold: 100},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("returns an error without the account identification", func(t *testing.T) {
+		var b strings.Builder
+
+		s := testStatement()
+		s.AccountID = ""
+
+		err := Write(&b, s, Version102)
+		require.ErrorIs(t, err, ErrAccountRequired)
+		require.Empty(t, b.String())
+	})
+
+	t.Run("returns an error for unsupported versions", func(t *testing.T) {
+		var b strings.Builder
+
+		err := Write(&b, testStatement(), Version(1))
+		require.Error(t, err)
+	})
+
+	t.Run("writes sgml statements", func(t *testing.T) {
+		var b strings.Builder
+
+		err := Write(&b, testStatement(), Version102)
+		require.NoError(t, err)
+
+		out := b.String()
+		require.True(t, strings.HasPrefix(out, "OFXHEADER:100\n"))
+		require.Contains(t, out, "<BANKID>077\n")
+		require.Contains(t, out, "<ACCTID>12345\n")
+		require.Contains(t, out, "<DTSTART>20220202\n")
+		require.Contains(t, out, "<DTEND>20220212\n")
+		require.Contains(t, out, "<TRNTYPE>XFER\n")
+		require.Contains(t, out, "<TRNAMT>22373.32\n")
+		require.Contains(t, out, "<TRNTYPE>PAYMENT\n")
+		require.Contains(t, out, "<TRNAMT>-100.00\n")
+		require.Contains(t, out, "<TRNTYPE>DEBIT\n")
+		require.Contains(t, out, "<MEMO>Boleto &lt;energia&gt; &amp; água\n")
+		require.Contains(t, out, "<BALAMT>1432.57\n")
+		require.NotContains(t, out, "</TRNAMT>")
+	})
+
+	t.Run("writes valid xml statements", func(t *testing.T) {
+		var b strings.Builder
+
+		s := testStatement()
+
+		err := Write(&b, s, Version220)
+		require.NoError(t, err)
+
+		var doc struct {
+			Transactions []struct {
+				Type   string `xml:"TRNTYPE"`
+				Amount string `xml:"TRNAMT"`
+				ID     string `xml:"FITID"`
+				Memo   string `xml:"MEMO"`
+			} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKTRANLIST>STMTTRN"`
+			LedgerBalance    string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>LEDGERBAL>BALAMT"`
+			AvailableBalance string `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>AVAILBAL>BALAMT"`
+		}
+
+		err = xml.Unmarshal([]byte(b.String()), &doc)
+		require.NoError(t, err)
+		require.Len(t, doc.Transactions, 3)
+		require.Equal(t, doc.Transactions[1].Memo, "Boleto <energia> & água")
+		require.Equal(t, doc.Transactions[2].Amount, "-1.50")
+		require.Equal(t, doc.LedgerBalance, "1532.57")
+		require.Equal(t, doc.AvailableBalance, "1432.57")
+
+		ids := inter.TransactionIDs(s.Transactions)
+		for i, v := range doc.Transactions {
+			require.Equal(t, v.ID, ids[i])
+		}
+	})
+}
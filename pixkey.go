@@ -0,0 +1,155 @@
+package inter
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidPixKey = errors.New("invalid pix key")
+
+type PixKeyType int
+
+const (
+	CPFPixKeyType = PixKeyType(iota + 1)
+	CNPJPixKeyType
+	EmailPixKeyType
+	PhonePixKeyType
+	EVPPixKeyType
+)
+
+func (t PixKeyType) String() string {
+	switch t {
+	case CPFPixKeyType:
+		return "cpf"
+	case CNPJPixKeyType:
+		return "cnpj"
+	case EmailPixKeyType:
+		return "email"
+	case PhonePixKeyType:
+		return "phone"
+	case EVPPixKeyType:
+		return "evp"
+	}
+
+	return "invalid"
+}
+
+// PixKey holds a normalized Pix key along with its detected type. Phone
+// keys are normalized to the +5500000000000 format, emails and random keys
+// (EVP) to lowercase and documents to digits only.
+type PixKey struct {
+	Type  PixKeyType
+	Value string
+}
+
+const maxEmailPixKeyLength = 77
+
+var (
+	evpPixKeyRegexp   = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	emailPixKeyRegexp = regexp.MustCompile(`^[a-z0-9.!#$&'*+/=?^_{|}~-]+@[a-z0-9](?:[a-z0-9-]*[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9-]*[a-z0-9])?)+$`)
+	phonePixKeyRegexp = regexp.MustCompile(`^\+[1-9][0-9]{9,13}$`)
+)
+
+// ParsePixKey detects the type of the key and normalizes it. Eleven digits
+// without a leading plus sign are always taken as a CPF, as the Pix
+// directory requires phone keys to carry the country code.
+func ParsePixKey(s string) (PixKey, error) {
+	s = strings.TrimSpace(s)
+
+	lower := strings.ToLower(s)
+
+	switch {
+	case evpPixKeyRegexp.MatchString(lower):
+		return PixKey{Type: EVPPixKeyType, Value: lower}, nil
+	case strings.Contains(s, "@"):
+		if len(lower) > maxEmailPixKeyLength || !emailPixKeyRegexp.MatchString(lower) {
+			return PixKey{}, ErrInvalidPixKey
+		}
+
+		return PixKey{Type: EmailPixKeyType, Value: lower}, nil
+	case strings.HasPrefix(s, "+") || strings.ContainsAny(s, "()"):
+		phone := normalizePhone(s)
+		if !phonePixKeyRegexp.MatchString(phone) {
+			return PixKey{}, ErrInvalidPixKey
+		}
+
+		return PixKey{Type: PhonePixKeyType, Value: phone}, nil
+	}
+
+	doc, err := ParseDocument(s)
+	if err != nil {
+		return PixKey{}, ErrInvalidPixKey
+	}
+
+	switch doc.(type) {
+	case CPF:
+		return PixKey{Type: CPFPixKeyType, Value: doc.String()}, nil
+	case CNPJ:
+		return PixKey{Type: CNPJPixKeyType, Value: doc.String()}, nil
+	}
+
+	return PixKey{}, ErrInvalidPixKey
+}
+
+func (k PixKey) String() string {
+	return k.Value
+}
+
+// Mask hides most of the key, which is suitable for logs. Keys too short
+// for their type are hidden as a whole.
+func (k PixKey) Mask() string {
+	switch k.Type {
+	case CPFPixKeyType:
+		return CPF(k.Value).Mask()
+	case CNPJPixKeyType:
+		return CNPJ(k.Value).Mask()
+	case EmailPixKeyType:
+		if user, domain, ok := strings.Cut(k.Value, "@"); ok && user != "" {
+			return user[:1] + strings.Repeat("*", len(user)-1) + "@" + domain
+		}
+	case PhonePixKeyType:
+		if n := len(k.Value); n >= 7 {
+			return k.Value[:3] + strings.Repeat("*", n-7) + k.Value[n-4:]
+		}
+	case EVPPixKeyType:
+		if len(k.Value) == 36 {
+			return k.Value[:8] + "-****-****-****-" + strings.Repeat("*", 8) + k.Value[32:]
+		}
+	}
+
+	return strings.Repeat("*", len(k.Value))
+}
+
+func (k PixKey) MarshalText() ([]byte, error) {
+	return []byte(k.Value), nil
+}
+
+func (k *PixKey) UnmarshalText(b []byte) error {
+	v, err := ParsePixKey(string(b))
+	if err != nil {
+		return err
+	}
+
+	*k = v
+
+	return nil
+}
+
+// Formatted Brazilian numbers without the country code get +55 prepended.
+func normalizePhone(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if r == '+' || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+
+	phone := b.String()
+	if !strings.HasPrefix(phone, "+") {
+		phone = "+55" + phone
+	}
+
+	return phone
+}
@@ -0,0 +1,114 @@
+package inter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePixKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  PixKey
+		mask  string
+	}{
+		{
+			name:  "cpf",
+			input: "529.982.247-25",
+			want:  PixKey{Type: CPFPixKeyType, Value: "52998224725"},
+			mask:  "***.982.247-**",
+		},
+		{
+			name:  "cnpj",
+			input: "11222333000181",
+			want:  PixKey{Type: CNPJPixKeyType, Value: "11222333000181"},
+			mask:  "**.***.333/0001-**",
+		},
+		{
+			name:  "email",
+			input: "Fulano@Example.com",
+			want:  PixKey{Type: EmailPixKeyType, Value: "fulano@example.com"},
+			mask:  "f*****@example.com",
+		},
+		{
+			name:  "phone",
+			input: "+5511999998888",
+			want:  PixKey{Type: PhonePixKeyType, Value: "+5511999998888"},
+			mask:  "+55*******8888",
+		},
+		{
+			name:  "formatted phone",
+			input: "(11) 99999-8888",
+			want:  PixKey{Type: PhonePixKeyType, Value: "+5511999998888"},
+			mask:  "+55*******8888",
+		},
+		{
+			name:  "evp",
+			input: "123E4567-E89B-12D3-A456-426614174000",
+			want:  PixKey{Type: EVPPixKeyType, Value: "123e4567-e89b-12d3-a456-426614174000"},
+			mask:  "123e4567-****-****-****-********4000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("detects "+tt.name+" keys", func(t *testing.T) {
+			got, err := ParsePixKey(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, got, tt.want)
+			require.Equal(t, got.Mask(), tt.mask)
+		})
+	}
+
+	t.Run("returns an error for invalid keys", func(t *testing.T) {
+		for _, input := range []string{"", "fulano@", "+55", "52998224724", "random"} {
+			_, err := ParsePixKey(input)
+			require.ErrorIs(t, err, ErrInvalidPixKey, input)
+		}
+	})
+}
+
+func TestPixKeyMask(t *testing.T) {
+	tests := []struct {
+		name string
+		key  PixKey
+		want string
+	}{
+		{
+			name: "email without user",
+			key:  PixKey{Type: EmailPixKeyType, Value: "@example.com"},
+			want: "************",
+		},
+		{
+			name: "email without domain",
+			key:  PixKey{Type: EmailPixKeyType, Value: "fulano"},
+			want: "******",
+		},
+		{
+			name: "short phone",
+			key:  PixKey{Type: PhonePixKeyType, Value: "+5511"},
+			want: "*****",
+		},
+		{
+			name: "short cpf",
+			key:  PixKey{Type: CPFPixKeyType, Value: "1234"},
+			want: "****",
+		},
+		{
+			name: "short evp",
+			key:  PixKey{Type: EVPPixKeyType, Value: "123e4567"},
+			want: "********",
+		},
+		{
+			name: "empty",
+			key:  PixKey{Type: EVPPixKeyType},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("hides the whole "+tt.name+" key", func(t *testing.T) {
+			require.Equal(t, tt.want, tt.key.Mask())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package reconcile
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is an expected ledger entry, such as an invoice receivable or a bill
+// payable. Positive amounts are expected credits and negative amounts are
+// expected debits.
+type Entry struct {
+	ID          string
+	Date        time.Time
+	Amount      float32
+	Reference   string
+	Description string
+}
+
+// ReadCSV reads ledger entries from a CSV file with a header row. The date
+// and amount columns are required, while id, reference and description are
+// optional and other columns are ignored. Dates are formatted as YYYY-MM-DD
+// or DD/MM/YYYY and amounts may use a decimal comma, as in 1.234,56.
+func ReadCSV(r io.Reader) ([]Entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing header")
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, v := range header {
+		columns[strings.ToLower(strings.TrimSpace(v))] = i
+	}
+
+	for _, v := range []string{"date", "amount"} {
+		if _, ok := columns[v]; !ok {
+			return nil, fmt.Errorf("missing %s column", v)
+		}
+	}
+
+	field := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+
+		return strings.TrimSpace(row[i])
+	}
+
+	var entries []Entry
+
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		date, err := parseDate(field(row, "date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		amount, err := parseAmount(field(row, "amount"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		e := Entry{
+			ID:          field(row, "id"),
+			Date:        date,
+			Amount:      amount,
+			Reference:   field(row, "reference"),
+			Description: field(row, "description"),
+		}
+
+		if e.ID == "" {
+			e.ID = strconv.Itoa(line)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, "02/01/2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+func parseAmount(s string) (float32, error) {
+	tmp := strings.ReplaceAll(s, " ", "")
+
+	if strings.Contains(tmp, ",") {
+		tmp = strings.ReplaceAll(tmp, ".", "")
+		tmp = strings.ReplaceAll(tmp, ",", ".")
+	}
+
+	v, err := strconv.ParseFloat(tmp, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	return float32(v), nil
+}
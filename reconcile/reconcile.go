@@ -0,0 +1,365 @@
+package reconcile
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+const (
+	DefaultDateTolerance = 3
+	DefaultMaxGroupSize  = 5
+
+	// Candidates considered when searching the entries paid by a single
+	// transaction, bounding the search.
+	maxGroupCandidates = 20
+)
+
+type Options struct {
+	// DateTolerance is the maximum number of days between the transaction
+	// and the entry dates, zero matching only the same day.
+	DateTolerance int
+	// MaxGroupSize is the maximum number of entries matched by a single
+	// transaction, DefaultMaxGroupSize when zero and one to disable
+	// one-to-many matches.
+	MaxGroupSize int
+	// MinSimilarity between the entry reference and description and the
+	// transaction title and description, from 0 to 1.
+	MinSimilarity float64
+}
+
+type BankItem struct {
+	ID string
+	inter.Transaction
+}
+
+// Match is a transaction matched to one or more entries, whose amounts sum
+// to the transaction value.
+type Match struct {
+	BankItem
+	Entries []Entry
+	// Similarity is the average similarity of the entries.
+	Similarity float64
+}
+
+type Result struct {
+	Matched         []Match
+	UnmatchedBank   []BankItem
+	UnmatchedLedger []Entry
+}
+
+// Reconcile matches transactions to entries with the same amount within the
+// date tolerance, preferring the most similar pairs and then the closest
+// dates. Transactions left are then matched to groups of entries whose
+// amounts sum to the transaction value, as a single transfer paying several
+// invoices.
+func Reconcile(transactions []inter.Transaction, entries []Entry, opts Options) Result {
+	if opts.MaxGroupSize <= 0 {
+		opts.MaxGroupSize = DefaultMaxGroupSize
+	}
+
+	bank := make([]BankItem, 0, len(transactions))
+	for i, id := range inter.TransactionIDs(transactions) {
+		bank = append(bank, BankItem{ID: id, Transaction: transactions[i]})
+	}
+
+	sort.SliceStable(bank, func(i, j int) bool {
+		return bank[i].Date.Before(bank[j].Date)
+	})
+
+	r := reconciler{
+		opts:        opts,
+		bank:        bank,
+		entries:     entries,
+		bankUsed:    make([]bool, len(bank)),
+		entriesUsed: make([]bool, len(entries)),
+		bankText:    make([]string, len(bank)),
+	}
+
+	for i, v := range bank {
+		r.bankText[i] = v.Title + " " + v.Description
+	}
+
+	matches := r.matchPairs()
+	matches = append(matches, r.matchGroups()...)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Date.Before(matches[j].Date)
+	})
+
+	result := Result{Matched: matches}
+
+	for i, v := range bank {
+		if !r.bankUsed[i] {
+			result.UnmatchedBank = append(result.UnmatchedBank, v)
+		}
+	}
+
+	for i, v := range entries {
+		if !r.entriesUsed[i] {
+			result.UnmatchedLedger = append(result.UnmatchedLedger, v)
+		}
+	}
+
+	return result
+}
+
+type reconciler struct {
+	opts        Options
+	bank        []BankItem
+	entries     []Entry
+	bankUsed    []bool
+	entriesUsed []bool
+	bankText    []string
+}
+
+type candidate struct {
+	bank       int
+	entry      int
+	similarity float64
+	days       int
+}
+
+func sortCandidates(c []candidate) {
+	sort.SliceStable(c, func(i, j int) bool {
+		if c[i].similarity != c[j].similarity {
+			return c[i].similarity > c[j].similarity
+		}
+
+		return c[i].days < c[j].days
+	})
+}
+
+// candidate returns whether the entry may be matched to the transaction,
+// ignoring the amount.
+func (r *reconciler) candidate(bank, entry int) (candidate, bool) {
+	days := daysBetween(r.bank[bank].Date, r.entries[entry].Date)
+	if days > r.opts.DateTolerance {
+		return candidate{}, false
+	}
+
+	e := r.entries[entry]
+
+	s := Similarity(e.Reference, e.Description, r.bankText[bank])
+	if s < r.opts.MinSimilarity {
+		return candidate{}, false
+	}
+
+	return candidate{bank: bank, entry: entry, similarity: s, days: days}, true
+}
+
+func (r *reconciler) matchPairs() []Match {
+	var candidates []candidate
+
+	for i, b := range r.bank {
+		value := b.Transaction.SignedCents()
+
+		for j, e := range r.entries {
+			if inter.Cents(e.Amount) != value {
+				continue
+			}
+
+			if c, ok := r.candidate(i, j); ok {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	sortCandidates(candidates)
+
+	var matches []Match
+
+	for _, c := range candidates {
+		if r.bankUsed[c.bank] || r.entriesUsed[c.entry] {
+			continue
+		}
+
+		r.bankUsed[c.bank] = true
+		r.entriesUsed[c.entry] = true
+
+		matches = append(matches, Match{
+			BankItem:   r.bank[c.bank],
+			Entries:    []Entry{r.entries[c.entry]},
+			Similarity: c.similarity,
+		})
+	}
+
+	return matches
+}
+
+func (r *reconciler) matchGroups() []Match {
+	if r.opts.MaxGroupSize < 2 {
+		return nil
+	}
+
+	var matches []Match
+
+	for i, b := range r.bank {
+		if r.bankUsed[i] {
+			continue
+		}
+
+		value := b.Transaction.SignedCents()
+
+		var candidates []candidate
+
+		for j, e := range r.entries {
+			amount := inter.Cents(e.Amount)
+
+			// Entries must have the transaction sign and a smaller
+			// amount, as the group has at least two of them.
+			if r.entriesUsed[j] || amount == 0 || (amount > 0) != (value > 0) || abs(amount) >= abs(value) {
+				continue
+			}
+
+			if c, ok := r.candidate(i, j); ok {
+				candidates = append(candidates, c)
+			}
+		}
+
+		sortCandidates(candidates)
+
+		if len(candidates) > maxGroupCandidates {
+			candidates = candidates[:maxGroupCandidates]
+		}
+
+		group := r.findGroup(candidates, value)
+		if group == nil {
+			continue
+		}
+
+		m := Match{BankItem: b}
+
+		r.bankUsed[i] = true
+
+		for _, c := range group {
+			r.entriesUsed[c.entry] = true
+			m.Entries = append(m.Entries, r.entries[c.entry])
+			m.Similarity += c.similarity
+		}
+
+		m.Similarity /= float64(len(group))
+
+		sort.SliceStable(m.Entries, func(i, j int) bool {
+			return m.Entries[i].Date.Before(m.Entries[j].Date)
+		})
+
+		matches = append(matches, m)
+	}
+
+	return matches
+}
+
+// findGroup searches the candidates in order for at least two of them
+// summing to the value, returning nil if there is none.
+func (r *reconciler) findGroup(candidates []candidate, value int64) []candidate {
+	var (
+		group  []candidate
+		search func(start int, remaining int64) bool
+	)
+
+	search = func(start int, remaining int64) bool {
+		if remaining == 0 {
+			return len(group) >= 2
+		}
+
+		if len(group) == r.opts.MaxGroupSize {
+			return false
+		}
+
+		for i := start; i < len(candidates); i++ {
+			amount := inter.Cents(r.entries[candidates[i].entry].Amount)
+			if abs(amount) > abs(remaining) {
+				continue
+			}
+
+			group = append(group, candidates[i])
+
+			if search(i+1, remaining-amount) {
+				return true
+			}
+
+			group = group[:len(group)-1]
+		}
+
+		return false
+	}
+
+	if !search(0, value) {
+		return nil
+	}
+
+	return group
+}
+
+// Similarity returns 1 when the reference is found in the text, ignoring
+// case, accents, spaces and punctuation, or the fraction of the reference
+// and description words found in the text otherwise. References shorter
+// than three characters are only compared as words.
+func Similarity(reference, description, text string) float64 {
+	textWords := words(text)
+
+	if ref := strings.Join(words(reference), ""); len(ref) >= 3 {
+		if strings.Contains(strings.Join(textWords, ""), ref) {
+			return 1
+		}
+	}
+
+	expected := words(reference + " " + description)
+	if len(expected) == 0 {
+		return 0
+	}
+
+	found := make(map[string]bool, len(textWords))
+	for _, v := range textWords {
+		found[v] = true
+	}
+
+	n := 0
+
+	for _, v := range expected {
+		if found[v] {
+			n++
+		}
+	}
+
+	return float64(n) / float64(len(expected))
+}
+
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "ê", "e", "è", "e",
+	"í", "i", "î", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ü", "u",
+	"ç", "c",
+)
+
+func words(s string) []string {
+	s = accents.Replace(strings.ToLower(s))
+
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func daysBetween(a, b time.Time) int {
+	d := a.Sub(b)
+	if d < 0 {
+		d = -d
+	}
+
+	return int(math.Round(d.Hours() / 24))
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,159 @@
+package reconcile
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+func testDate(day int) time.Time {
+	return time.Date(2022, time.February, day, 0, 0, 0, 0, time.UTC)
+}
+
+func credit(day int, value float32, title, description string) inter.Transaction {
+	return inter.Transaction{
+		Date:        testDate(day),
+		Type:        inter.PixTransactionType,
+		Operation:   inter.CreditTransactionOperation,
+		Value:       value,
+		Title:       title,
+		Description: description,
+	}
+}
+
+func debit(day int, value float32, title, description string) inter.Transaction {
+	t := credit(day, value, title, description)
+	t.Operation = inter.DebitTransactionOperation
+
+	return t
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Run("reads entries", func(t *testing.T) {
+		entries, err := ReadCSV(strings.NewReader(`ID,Date,Amount,Reference,Description,Customer
+inv-1,2022-02-01,150.00,NF 1001,Consulting,ACME
+,03/02/2022,"-1.234,56",,Rent,
+`))
+		require.NoError(t, err)
+		require.Equal(t, []Entry{
+			{ID: "inv-1", Date: testDate(1), Amount: 150, Reference: "NF 1001", Description: "Consulting"},
+			{ID: "3", Date: testDate(3), Amount: -1234.56, Description: "Rent"},
+		}, entries)
+	})
+
+	for _, data := range []string{
+		"",
+		"id,amount\n1,10\n",
+		"date,amount\n2022-02-30,10\n",
+		"date,amount\n2022-02-01,ten\n",
+	} {
+		_, err := ReadCSV(strings.NewReader(data))
+		require.Error(t, err, data)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	require.Equal(t, 1.0, Similarity("NF-1001", "", "PIX RECEBIDO NF 1001 ACME"))
+	require.Equal(t, 1.0, Similarity("", "Aluguel São Paulo", "ALUGUEL SAO PAULO"))
+	require.Equal(t, 0.5, Similarity("", "Energia elétrica", "Conta de energia"))
+	require.Equal(t, 0.0, Similarity("", "", "anything"))
+	require.Equal(t, 0.0, Similarity("1", "", "PIX 12345"))
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("matches one to one", func(t *testing.T) {
+		transactions := []inter.Transaction{
+			credit(2, 150, "Pix recebido", "PIX RECEBIDO - NF 1001 ACME"),
+			debit(4, 80, "Pagamento", "CONTA DE ENERGIA"),
+			credit(10, 99, "Pix recebido", "UNKNOWN"),
+		}
+
+		entries := []Entry{
+			{ID: "a", Date: testDate(3), Amount: -80, Description: "Energia"},
+			{ID: "b", Date: testDate(1), Amount: 150, Reference: "NF 1001"},
+			{ID: "c", Date: testDate(20), Amount: 99},
+		}
+
+		r := Reconcile(transactions, entries, Options{DateTolerance: 3})
+
+		require.Len(t, r.Matched, 2)
+		require.Equal(t, transactions[0], r.Matched[0].Transaction)
+		require.Equal(t, []Entry{entries[1]}, r.Matched[0].Entries)
+		require.Equal(t, 1.0, r.Matched[0].Similarity)
+		require.Equal(t, transactions[1], r.Matched[1].Transaction)
+		require.Equal(t, []Entry{entries[0]}, r.Matched[1].Entries)
+
+		require.Len(t, r.UnmatchedBank, 1)
+		require.Equal(t, transactions[2], r.UnmatchedBank[0].Transaction)
+		require.Equal(t, inter.TransactionIDs(transactions)[2], r.UnmatchedBank[0].ID)
+		require.Equal(t, []Entry{entries[2]}, r.UnmatchedLedger)
+	})
+
+	t.Run("prefers the most similar entry", func(t *testing.T) {
+		transactions := []inter.Transaction{
+			credit(2, 100, "Pix recebido", "PIX RECEBIDO - NF 2002"),
+		}
+
+		entries := []Entry{
+			{ID: "a", Date: testDate(2), Amount: 100, Reference: "NF 2001"},
+			{ID: "b", Date: testDate(3), Amount: 100, Reference: "NF 2002"},
+		}
+
+		r := Reconcile(transactions, entries, Options{DateTolerance: 3})
+
+		require.Len(t, r.Matched, 1)
+		require.Equal(t, "b", r.Matched[0].Entries[0].ID)
+		require.Equal(t, []Entry{entries[0]}, r.UnmatchedLedger)
+	})
+
+	t.Run("matches one to many", func(t *testing.T) {
+		transactions := []inter.Transaction{
+			credit(5, 350.5, "TED recebida", "TED RECEBIDA ACME LTDA"),
+		}
+
+		entries := []Entry{
+			{ID: "a", Date: testDate(5), Amount: 100.25, Description: "ACME"},
+			{ID: "b", Date: testDate(4), Amount: 250.25, Description: "ACME"},
+			{ID: "c", Date: testDate(5), Amount: 200, Description: "Other"},
+			{ID: "d", Date: testDate(5), Amount: -250.25, Description: "ACME"},
+		}
+
+		r := Reconcile(transactions, entries, Options{DateTolerance: 3})
+
+		require.Len(t, r.Matched, 1)
+		require.Equal(t, []Entry{entries[1], entries[0]}, r.Matched[0].Entries)
+		require.Empty(t, r.UnmatchedBank)
+		require.Equal(t, []Entry{entries[2], entries[3]}, r.UnmatchedLedger)
+	})
+
+	t.Run("does not match groups larger than the maximum", func(t *testing.T) {
+		transactions := []inter.Transaction{credit(5, 30, "TED recebida", "")}
+
+		entries := []Entry{
+			{Date: testDate(5), Amount: 10},
+			{Date: testDate(5), Amount: 10},
+			{Date: testDate(5), Amount: 10},
+		}
+
+		r := Reconcile(transactions, entries, Options{MaxGroupSize: 2})
+		require.Empty(t, r.Matched)
+
+		r = Reconcile(transactions, entries, Options{MaxGroupSize: 3})
+		require.Len(t, r.Matched, 1)
+		require.Len(t, r.Matched[0].Entries, 3)
+	})
+
+	t.Run("requires the minimum similarity", func(t *testing.T) {
+		transactions := []inter.Transaction{credit(5, 30, "Pix recebido", "FULANO")}
+		entries := []Entry{{Date: testDate(5), Amount: 30, Description: "Beltrano"}}
+
+		r := Reconcile(transactions, entries, Options{MinSimilarity: 0.5})
+		require.Empty(t, r.Matched)
+
+		r = Reconcile(transactions, entries, Options{})
+		require.Len(t, r.Matched, 1)
+	})
+}
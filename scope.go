@@ -0,0 +1,155 @@
+package inter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scope is an OAuth scope of the Inter API.
+type Scope string
+
+const (
+	ExtratoReadScope          Scope = "extrato.read"
+	BoletoCobrancaReadScope   Scope = "boleto-cobranca.read"
+	BoletoCobrancaWriteScope  Scope = "boleto-cobranca.write"
+	PagamentoBoletoReadScope  Scope = "pagamento-boleto.read"
+	PagamentoBoletoWriteScope Scope = "pagamento-boleto.write"
+	PagamentoDarfWriteScope   Scope = "pagamento-darf.write"
+	PagamentoLoteReadScope    Scope = "pagamento-lote.read"
+	PagamentoLoteWriteScope   Scope = "pagamento-lote.write"
+	PagamentoPixReadScope     Scope = "pagamento-pix.read"
+	PagamentoPixWriteScope    Scope = "pagamento-pix.write"
+	CobReadScope              Scope = "cob.read"
+	CobWriteScope             Scope = "cob.write"
+	CobvReadScope             Scope = "cobv.read"
+	CobvWriteScope            Scope = "cobv.write"
+	PixReadScope              Scope = "pix.read"
+	PixWriteScope             Scope = "pix.write"
+	PayloadLocationReadScope  Scope = "payloadlocation.read"
+	PayloadLocationWriteScope Scope = "payloadlocation.write"
+	WebhookReadScope          Scope = "webhook.read"
+	WebhookWriteScope         Scope = "webhook.write"
+	WebhookBankingReadScope   Scope = "webhook-banking.read"
+	WebhookBankingWriteScope  Scope = "webhook-banking.write"
+)
+
+var allScopes = []Scope{
+	ExtratoReadScope,
+	BoletoCobrancaReadScope,
+	BoletoCobrancaWriteScope,
+	PagamentoBoletoReadScope,
+	PagamentoBoletoWriteScope,
+	PagamentoDarfWriteScope,
+	PagamentoLoteReadScope,
+	PagamentoLoteWriteScope,
+	PagamentoPixReadScope,
+	PagamentoPixWriteScope,
+	CobReadScope,
+	CobWriteScope,
+	CobvReadScope,
+	CobvWriteScope,
+	PixReadScope,
+	PixWriteScope,
+	PayloadLocationReadScope,
+	PayloadLocationWriteScope,
+	WebhookReadScope,
+	WebhookWriteScope,
+	WebhookBankingReadScope,
+	WebhookBankingWriteScope,
+}
+
+// Scopes returns every known scope.
+func Scopes() []Scope {
+	return append([]Scope(nil), allScopes...)
+}
+
+func ParseScope(s string) (Scope, error) {
+	s = strings.TrimSpace(s)
+
+	for _, v := range allScopes {
+		if string(v) == s {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown scope %q", s)
+}
+
+// ParseScopes parses a comma or space separated list of scopes.
+func ParseScopes(s string) ([]Scope, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	scopes := make([]Scope, 0, len(fields))
+	for _, f := range fields {
+		v, err := ParseScope(f)
+		if err != nil {
+			return nil, err
+		}
+
+		scopes = append(scopes, v)
+	}
+
+	return scopes, nil
+}
+
+// RequiredScopes maps each service method calling the API to the scopes
+// its token needs.
+var RequiredScopes = map[string][]Scope{
+	"Banking.Balance":      {ExtratoReadScope},
+	"Banking.Transactions": {ExtratoReadScope},
+	"Banking.Statement":    {ExtratoReadScope},
+}
+
+// ScopeError is returned by services checking scopes when the token lacks
+// the scopes required by a method.
+type ScopeError struct {
+	Method  string
+	Missing []Scope
+}
+
+func (e *ScopeError) Error() string {
+	missing := make([]string, len(e.Missing))
+	for i, v := range e.Missing {
+		missing[i] = string(v)
+	}
+
+	return fmt.Sprintf("%s requires the token scopes %s", e.Method, strings.Join(missing, ", "))
+}
+
+// MissingScopes returns the required scopes the token was not granted.
+func (t Token) MissingScopes(required ...Scope) []Scope {
+	var missing []Scope
+
+	for _, r := range required {
+		found := false
+		for _, s := range t.Scopes {
+			if s == string(r) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, r)
+		}
+	}
+
+	return missing
+}
+
+// checkScopes returns a ScopeError if the token lacks the scopes required
+// by the method. Tokens without scopes, such as those from TokenFromString,
+// are not checked, since their scopes are unknown.
+func checkScopes(t Token, method string) error {
+	if len(t.Scopes) == 0 {
+		return nil
+	}
+
+	if missing := t.MissingScopes(RequiredScopes[method]...); len(missing) > 0 {
+		return &ScopeError{Method: method, Missing: missing}
+	}
+
+	return nil
+}
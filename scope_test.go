@@ -0,0 +1,104 @@
+package inter
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopes(t *testing.T) {
+	t.Run("parses comma and space separated scopes", func(t *testing.T) {
+		scopes, err := ParseScopes("extrato.read, pagamento-pix.write cob.read")
+		require.NoError(t, err)
+		require.Equal(t, []Scope{ExtratoReadScope, PagamentoPixWriteScope, CobReadScope}, scopes)
+	})
+
+	t.Run("returns an error on unknown scopes", func(t *testing.T) {
+		_, err := ParseScopes("extrato.read,extrato.write")
+		require.ErrorContains(t, err, `unknown scope "extrato.write"`)
+	})
+
+	t.Run("knows every required scope", func(t *testing.T) {
+		for method, scopes := range RequiredScopes {
+			for _, s := range scopes {
+				_, err := ParseScope(string(s))
+				require.NoError(t, err, method)
+			}
+		}
+	})
+}
+
+func TestTokenMissingScopes(t *testing.T) {
+	token := Token{Scopes: []string{"extrato.read", "pix.read"}}
+
+	require.Empty(t, token.MissingScopes(ExtratoReadScope, PixReadScope))
+	require.Equal(t, []Scope{PixWriteScope}, token.MissingScopes(ExtratoReadScope, PixWriteScope))
+}
+
+func TestBankingWithScopeCheck(t *testing.T) {
+	calls := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintln(w, `{"disponivel": 1, "transacoes": []}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(tls.Certificate{})
+	client.apiBaseUrl = ts.URL
+
+	t.Run("fails before calling the API", func(t *testing.T) {
+		banking := NewBanking(client, Token{Scopes: []string{"pix.read"}}).WithScopeCheck()
+
+		_, err := banking.Balance(context.Background(), time.Now())
+
+		var e *ScopeError
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, "Banking.Balance", e.Method)
+		require.Equal(t, []Scope{ExtratoReadScope}, e.Missing)
+		require.EqualError(t, err, "Banking.Balance requires the token scopes extrato.read")
+
+		_, err = banking.Statement(context.Background(), time.Now(), time.Now())
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, "Banking.Statement", e.Method)
+
+		require.Zero(t, calls)
+	})
+
+	t.Run("calls the API when the token has the scopes", func(t *testing.T) {
+		banking := NewBanking(client, Token{Scopes: []string{"extrato.read"}}).WithScopeCheck()
+
+		_, err := banking.Transactions(context.Background(), time.Now(), time.Now())
+		require.NoError(t, err)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("does not check tokens without scopes", func(t *testing.T) {
+		banking := NewBanking(client, TokenFromString("token")).WithScopeCheck()
+
+		_, err := banking.Balance(context.Background(), time.Now())
+		require.NoError(t, err)
+	})
+
+	t.Run("does not check tokens with an empty scope response", func(t *testing.T) {
+		token, err := parseApiResponseToken([]byte(`{"access_token": "token", "scope": ""}`))
+		require.NoError(t, err)
+
+		_, err = NewBanking(client, token).WithScopeCheck().Balance(context.Background(), time.Now())
+		require.NoError(t, err)
+	})
+
+	t.Run("does not check by default", func(t *testing.T) {
+		banking := NewBanking(client, Token{Scopes: []string{"pix.read"}})
+
+		_, err := banking.Balance(context.Background(), time.Now())
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,134 @@
+package inter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var ErrStatementGap = errors.New("statement balances do not add up")
+
+type StatementEntry struct {
+	Transaction
+
+	// Balance after the transaction.
+	Balance float32
+}
+
+type Statement struct {
+	Start time.Time
+	End   time.Time
+
+	OpeningBalance float32
+	ClosingBalance float32
+
+	Entries []StatementEntry
+
+	// Gap is the difference between the opening balance reported by the
+	// bank and the one reconstructed from the transactions. A non-zero gap
+	// means transactions are missing from the statement.
+	Gap float32
+}
+
+// NewStatement reconstructs the opening balance and the balance after each
+// transaction walking backwards from the closing balance, which must be the
+// balance at the end of the period. Transactions are sorted by date, keeping
+// the original order of transactions from the same day.
+func NewStatement(start, end time.Time, closing Balance, transactions []Transaction) Statement {
+	sorted := make([]Transaction, len(transactions))
+	copy(sorted, transactions)
+
+	SortTransactions(sorted, ByDate)
+
+	entries := make([]StatementEntry, len(sorted))
+	balance := Cents(closing.Available)
+
+	for i := len(sorted) - 1; i >= 0; i-- {
+		entries[i] = StatementEntry{
+			Transaction: sorted[i],
+			Balance:     FromCents(balance),
+		}
+
+		balance -= sorted[i].SignedCents()
+	}
+
+	return Statement{
+		Start:          start,
+		End:            end,
+		OpeningBalance: FromCents(balance),
+		ClosingBalance: closing.Available,
+		Entries:        entries,
+	}
+}
+
+// Check compares the reconstructed opening balance with the one reported by
+// the bank, which must be the balance at the end of the day before the
+// period start, recording the difference as the statement gap.
+func (s *Statement) Check(opening Balance) error {
+	gap := Cents(opening.Available) - Cents(s.OpeningBalance)
+
+	s.Gap = FromCents(gap)
+
+	if gap != 0 {
+		return fmt.Errorf("%w: expected opening balance %.2f, got %.2f",
+			ErrStatementGap, opening.Available, s.OpeningBalance)
+	}
+
+	return nil
+}
+
+func (s Statement) Consistent() bool {
+	return Cents(s.Gap) == 0
+}
+
+// Statement fetches the transactions and the balances needed to reconstruct
+// the running balance of the period. Inconsistencies are not reported as
+// errors, but through the statement gap.
+func (b *Banking) Statement(ctx context.Context, start, end time.Time) (Statement, error) {
+	if err := b.requireScopes("Banking.Statement"); err != nil {
+		return Statement{}, err
+	}
+
+	transactions, err := b.Transactions(ctx, start, end)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	closing, err := b.Balance(ctx, end)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	opening, err := b.Balance(ctx, start.AddDate(0, 0, -1))
+	if err != nil {
+		return Statement{}, err
+	}
+
+	s := NewStatement(start, end, closing, transactions)
+	_ = s.Check(opening)
+
+	return s, nil
+}
+
+// Cents returns the value in cents. Balances and transactions values are
+// float32, so they are summed as cents to avoid accumulating rounding
+// errors.
+func Cents(v float32) int64 {
+	return int64(math.Round(float64(v) * 100))
+}
+
+// FromCents returns the value of the cents.
+func FromCents(c int64) float32 {
+	return float32(float64(c) / 100)
+}
+
+// SignedCents returns the transaction value in cents, negative for debits.
+func (t Transaction) SignedCents() int64 {
+	if t.Operation == DebitTransactionOperation {
+		return -Cents(t.Value)
+	}
+
+	return Cents(t.Value)
+}
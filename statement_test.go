@@ -0,0 +1,124 @@
+package inter
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatement(t *testing.T) {
+	date := time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)
+
+	transactions := []Transaction{
+		{Date: date.AddDate(0, 0, 2), Operation: DebitTransactionOperation, Value: 0.3},
+		{Date: date, Operation: CreditTransactionOperation, Value: 100.1},
+		{Date: date.AddDate(0, 0, 2), Operation: DebitTransactionOperation, Value: 0.2},
+	}
+
+	t.Run("reconstructs running balances", func(t *testing.T) {
+		s := NewStatement(date, date.AddDate(0, 0, 2), Balance{Available: 150.6}, transactions)
+
+		require.Equal(t, s.OpeningBalance, float32(51))
+		require.Equal(t, s.ClosingBalance, float32(150.6))
+		require.Len(t, s.Entries, 3)
+
+		require.Equal(t, s.Entries[0].Value, float32(100.1))
+		require.Equal(t, s.Entries[0].Balance, float32(151.1))
+		require.Equal(t, s.Entries[1].Value, float32(0.3))
+		require.Equal(t, s.Entries[1].Balance, float32(150.8))
+		require.Equal(t, s.Entries[2].Value, float32(0.2))
+		require.Equal(t, s.Entries[2].Balance, float32(150.6))
+	})
+
+	t.Run("keeps the input order", func(t *testing.T) {
+		_ = NewStatement(date, date, Balance{}, transactions)
+		require.Equal(t, transactions[0].Value, float32(0.3))
+	})
+
+	t.Run("returns no error if balances add up", func(t *testing.T) {
+		s := NewStatement(date, date.AddDate(0, 0, 2), Balance{Available: 150.6}, transactions)
+
+		err := s.Check(Balance{Available: 51})
+		require.NoError(t, err)
+		require.True(t, s.Consistent())
+	})
+
+	t.Run("flags gaps", func(t *testing.T) {
+		s := NewStatement(date, date.AddDate(0, 0, 2), Balance{Available: 150.6}, transactions)
+
+		err := s.Check(Balance{Available: 61})
+		require.ErrorIs(t, err, ErrStatementGap)
+		require.False(t, s.Consistent())
+		require.Equal(t, s.Gap, float32(10))
+	})
+}
+
+func TestCents(t *testing.T) {
+	require.Equal(t, int64(1999), Cents(19.99))
+	require.Equal(t, int64(153257), Cents(1532.57))
+	require.Equal(t, float32(19.99), FromCents(1999))
+
+	require.Equal(t, int64(-2230000), Transaction{Operation: DebitTransactionOperation, Value: 22300}.SignedCents())
+	require.Equal(t, int64(2237332), Transaction{Operation: CreditTransactionOperation, Value: 22373.32}.SignedCents())
+}
+
+func TestBankingStatement(t *testing.T) {
+	t.Run("returns an error on context cancelation", func(t *testing.T) {
+		client := NewClient(tls.Certificate{})
+
+		banking := NewBanking(client, Token{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := banking.Statement(ctx, time.Now(), time.Now())
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("fetches balances around the period", func(t *testing.T) {
+		start := time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(0, 0, 10)
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/banking/v2/extrato":
+				fmt.Fprintln(w, `{"transacoes": [{
+	"dataEntrada": "2022-02-05",
+	"tipoTransacao": "PIX",
+	"tipoOperacao": "C",
+	"valor": "10.00",
+	"titulo": "Pix recebido",
+	"descricao": ""
+}]}`)
+			case "/banking/v2/saldo":
+				switch r.URL.Query().Get("dataSaldo") {
+				case "2022-02-01":
+					fmt.Fprintln(w, `{"disponivel": 5}`)
+				case "2022-02-12":
+					fmt.Fprintln(w, `{"disponivel": 20}`)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}
+		}))
+		defer ts.Close()
+
+		client := NewClient(tls.Certificate{})
+		client.apiBaseUrl = ts.URL
+
+		banking := NewBanking(client, Token{})
+
+		s, err := banking.Statement(context.Background(), start, end)
+		require.NoError(t, err)
+		require.Equal(t, s.OpeningBalance, float32(10))
+		require.Equal(t, s.ClosingBalance, float32(20))
+		require.Equal(t, s.Gap, float32(-5))
+		require.False(t, s.Consistent())
+	})
+}
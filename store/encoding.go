@@ -0,0 +1,155 @@
+package store
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+// The stored format uses names instead of the enum values, so files stay
+// readable and do not depend on the constants order.
+
+type storedAccount struct {
+	LastSynced   string                   `json:"last_synced,omitempty"`
+	Transactions []storedTransaction      `json:"transactions"`
+	Balances     map[string]storedBalance `json:"balances"`
+}
+
+type storedTransaction struct {
+	ID          string `json:"id"`
+	Date        string `json:"date"`
+	Type        string `json:"type"`
+	Operation   string `json:"operation"`
+	Value       string `json:"value"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type storedBalance struct {
+	Available               string `json:"available"`
+	Limit                   string `json:"limit"`
+	CheckOnHold             string `json:"check_on_hold"`
+	JudiciallyBlocked       string `json:"judicially_blocked"`
+	AdministrativelyBlocked string `json:"administratively_blocked"`
+}
+
+func storedAccountFrom(a *Account) storedAccount {
+	tmp := storedAccount{
+		Transactions: make([]storedTransaction, 0, len(a.Transactions)),
+		Balances:     make(map[string]storedBalance, len(a.Balances)),
+	}
+
+	if !a.LastSynced.IsZero() {
+		tmp.LastSynced = a.LastSynced.Format(time.DateOnly)
+	}
+
+	for _, v := range a.Transactions {
+		tmp.Transactions = append(tmp.Transactions, storedTransaction{
+			ID:          v.ID,
+			Date:        v.Date.Format(time.DateOnly),
+			Type:        v.Type.String(),
+			Operation:   v.Operation.String(),
+			Value:       formatValue(v.Value),
+			Title:       v.Title,
+			Description: v.Description,
+		})
+	}
+
+	for k, v := range a.Balances {
+		tmp.Balances[k] = storedBalance{
+			Available:               formatValue(v.Available),
+			Limit:                   formatValue(v.Limit),
+			CheckOnHold:             formatValue(v.CheckOnHold),
+			JudiciallyBlocked:       formatValue(v.JudiciallyBlocked),
+			AdministrativelyBlocked: formatValue(v.AdministrativelyBlocked),
+		}
+	}
+
+	return tmp
+}
+
+func accountFromStored(tmp storedAccount) (*Account, error) {
+	a := &Account{
+		Transactions: make([]Transaction, 0, len(tmp.Transactions)),
+		Balances:     make(map[string]inter.Balance, len(tmp.Balances)),
+	}
+
+	if tmp.LastSynced != "" {
+		date, err := time.Parse(time.DateOnly, tmp.LastSynced)
+		if err != nil {
+			return nil, err
+		}
+
+		a.LastSynced = date
+	}
+
+	for _, v := range tmp.Transactions {
+		date, err := time.Parse(time.DateOnly, v.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := parseValue(v.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		// Unknown names are kept as the zero value, as the API does for
+		// unknown types.
+		t, _ := inter.ParseTransactionType(v.Type)
+		o, _ := inter.ParseTransactionOperation(v.Operation)
+
+		a.Transactions = append(a.Transactions, Transaction{
+			ID: v.ID,
+			Transaction: inter.Transaction{
+				Date:        date,
+				Type:        t,
+				Operation:   o,
+				Value:       value,
+				Title:       v.Title,
+				Description: v.Description,
+			},
+		})
+	}
+
+	for k, v := range tmp.Balances {
+		var (
+			b   inter.Balance
+			err error
+		)
+
+		for _, f := range []struct {
+			dst *float32
+			src string
+		}{
+			{&b.Available, v.Available},
+			{&b.Limit, v.Limit},
+			{&b.CheckOnHold, v.CheckOnHold},
+			{&b.JudiciallyBlocked, v.JudiciallyBlocked},
+			{&b.AdministrativelyBlocked, v.AdministrativelyBlocked},
+		} {
+			if *f.dst, err = parseValue(f.src); err != nil {
+				return nil, err
+			}
+		}
+
+		a.Balances[k] = b
+	}
+
+	return a, nil
+}
+
+func formatValue(v float32) string {
+	return strconv.FormatFloat(float64(v), 'f', 2, 32)
+}
+
+func parseValue(s string) (float32, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseFloat(s, 32)
+
+	return float32(v), err
+}
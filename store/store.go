@@ -0,0 +1,168 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+const DefaultAccount = "default"
+
+// Store persists transactions and daily balances as one JSON file per
+// account inside a directory.
+type Store struct {
+	dir string
+}
+
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+type Transaction struct {
+	ID string
+	inter.Transaction
+}
+
+type Account struct {
+	// LastSynced is the end date of the last synchronized window.
+	LastSynced   time.Time
+	Transactions []Transaction
+	// Balances at the end of each day, indexed by the YYYY-MM-DD date.
+	Balances map[string]inter.Balance
+}
+
+// Load returns the stored account data, or an empty account if it was never
+// saved.
+func (s *Store) Load(account string) (*Account, error) {
+	path, err := s.path(account)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Account{Balances: map[string]inter.Balance{}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var tmp storedAccount
+
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	return accountFromStored(tmp)
+}
+
+// Save writes the account data to a temporary file renamed over the
+// previous one, so readers never see a partially written file.
+func (s *Store) Save(account string, a *Account) error {
+	path, err := s.path(account)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(storedAccountFrom(a), "", "\t")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+var accountRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+func (s *Store) path(account string) (string, error) {
+	if account == "" {
+		account = DefaultAccount
+	}
+
+	if !accountRegexp.MatchString(account) || account == "." || account == ".." {
+		return "", fmt.Errorf("invalid account name %q", account)
+	}
+
+	return filepath.Join(s.dir, account+".json"), nil
+}
+
+// Merge adds the transactions not yet stored, returning how many were added.
+// Identifiers are computed over the given transactions, which must contain
+// every transaction of the days they cover.
+func (a *Account) Merge(transactions []inter.Transaction) int {
+	seen := make(map[string]bool, len(a.Transactions))
+	for _, v := range a.Transactions {
+		seen[v.ID] = true
+	}
+
+	added := 0
+
+	for i, id := range inter.TransactionIDs(transactions) {
+		if seen[id] {
+			continue
+		}
+
+		a.Transactions = append(a.Transactions, Transaction{
+			ID:          id,
+			Transaction: transactions[i],
+		})
+		seen[id] = true
+		added++
+	}
+
+	sort.SliceStable(a.Transactions, func(i, j int) bool {
+		return a.Transactions[i].Date.Before(a.Transactions[j].Date)
+	})
+
+	return added
+}
+
+// Between returns the stored transactions in the given period.
+func (a *Account) Between(start, end time.Time) []inter.Transaction {
+	var transactions []inter.Transaction
+
+	for _, v := range a.Transactions {
+		if v.Date.Before(truncateDay(start)) || v.Date.After(truncateDay(end)) {
+			continue
+		}
+
+		transactions = append(transactions, v.Transaction)
+	}
+
+	return transactions
+}
+
+func (a *Account) Balance(date time.Time) (inter.Balance, bool) {
+	b, ok := a.Balances[date.Format(time.DateOnly)]
+	return b, ok
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+var testDate = time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)
+
+type fakeFetcher struct {
+	transactions []inter.Transaction
+	balance      inter.Balance
+	windows      [][2]time.Time
+	err          error
+}
+
+func (f *fakeFetcher) Transactions(ctx context.Context, start, end time.Time) ([]inter.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.windows = append(f.windows, [2]time.Time{start, end})
+
+	var transactions []inter.Transaction
+
+	for _, v := range f.transactions {
+		if !v.Date.Before(start) && !v.Date.After(end) {
+			transactions = append(transactions, v)
+		}
+	}
+
+	return transactions, nil
+}
+
+func (f *fakeFetcher) Balance(ctx context.Context, date time.Time) (inter.Balance, error) {
+	return f.balance, nil
+}
+
+func TestStore(t *testing.T) {
+	t.Run("returns an empty account if it was never saved", func(t *testing.T) {
+		s, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		a, err := s.Load("12345")
+		require.NoError(t, err)
+		require.True(t, a.LastSynced.IsZero())
+		require.Empty(t, a.Transactions)
+	})
+
+	t.Run("returns an error on invalid account names", func(t *testing.T) {
+		s, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		_, err = s.Load("../12345")
+		require.Error(t, err)
+	})
+
+	t.Run("saves and loads accounts", func(t *testing.T) {
+		dir := t.TempDir()
+
+		s, err := Open(dir)
+		require.NoError(t, err)
+
+		want := &Account{
+			LastSynced: testDate,
+			Balances: map[string]inter.Balance{
+				"2022-02-02": {Available: 10.5, Limit: 100},
+			},
+		}
+		want.Merge([]inter.Transaction{{
+			Date:        testDate,
+			Type:        inter.PixTransactionType,
+			Operation:   inter.CreditTransactionOperation,
+			Value:       10.5,
+			Title:       "Pix recebido",
+			Description: "PIX RECEBIDO",
+		}})
+
+		require.NoError(t, s.Save("", want))
+
+		info, err := os.Stat(filepath.Join(dir, DefaultAccount+".json"))
+		require.NoError(t, err)
+		require.Equal(t, info.Mode().Perm(), os.FileMode(0600))
+
+		got, err := s.Load("")
+		require.NoError(t, err)
+		require.Equal(t, got, want)
+	})
+}
+
+func TestAccountMerge(t *testing.T) {
+	t.Run("deduplicates transactions", func(t *testing.T) {
+		transaction := inter.Transaction{Date: testDate, Value: 1}
+
+		var a Account
+
+		require.Equal(t, a.Merge([]inter.Transaction{transaction}), 1)
+		require.Equal(t, a.Merge([]inter.Transaction{transaction, transaction}), 1)
+		require.Equal(t, a.Merge([]inter.Transaction{transaction, transaction}), 0)
+		require.Len(t, a.Transactions, 2)
+	})
+}
+
+func TestSync(t *testing.T) {
+	transactions := []inter.Transaction{
+		{Date: testDate, Operation: inter.CreditTransactionOperation, Value: 100},
+		{Date: testDate.AddDate(0, 0, 2), Operation: inter.DebitTransactionOperation, Value: 30},
+	}
+
+	t.Run("returns an error without start date on the first sync", func(t *testing.T) {
+		s, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		_, err = s.Sync(context.Background(), "", &fakeFetcher{}, SyncOptions{})
+		require.Error(t, err)
+	})
+
+	t.Run("returns fetch errors", func(t *testing.T) {
+		s, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		fetchErr := errors.New("rate limited")
+
+		_, err = s.Sync(context.Background(), "", &fakeFetcher{err: fetchErr}, SyncOptions{
+			Start: testDate,
+			End:   testDate,
+		})
+		require.ErrorIs(t, err, fetchErr)
+	})
+
+	t.Run("fetches windows and reconstructs balances", func(t *testing.T) {
+		s, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		f := &fakeFetcher{
+			transactions: transactions,
+			balance:      inter.Balance{Available: 120, Limit: 50},
+		}
+
+		result, err := s.Sync(context.Background(), "", f, SyncOptions{
+			Start:      testDate.AddDate(0, 0, -1),
+			End:        testDate.AddDate(0, 0, 3),
+			WindowDays: 2,
+		})
+		require.NoError(t, err)
+		require.Equal(t, result.Added, 2)
+		require.Len(t, f.windows, 3)
+		require.Equal(t, f.windows[2], [2]time.Time{testDate.AddDate(0, 0, 3), testDate.AddDate(0, 0, 3)})
+
+		a, err := s.Load("")
+		require.NoError(t, err)
+		require.Equal(t, a.LastSynced, testDate.AddDate(0, 0, 3))
+
+		for day, want := range []float32{50, 150, 150, 120, 120} {
+			b, ok := a.Balance(testDate.AddDate(0, 0, day-1))
+			require.True(t, ok)
+			require.Equal(t, b.Available, want)
+			require.Equal(t, b.Limit, float32(50))
+		}
+	})
+
+	t.Run("fetches only new windows with overlap", func(t *testing.T) {
+		s, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		f := &fakeFetcher{transactions: transactions[:1]}
+
+		_, err = s.Sync(context.Background(), "", f, SyncOptions{
+			Start: testDate,
+			End:   testDate.AddDate(0, 0, 1),
+		})
+		require.NoError(t, err)
+
+		// A late posting shows up for a date already synced.
+		f.transactions = append(f.transactions, inter.Transaction{
+			Date:  testDate.AddDate(0, 0, 1),
+			Value: 5,
+		})
+		f.windows = nil
+
+		result, err := s.Sync(context.Background(), "", f, SyncOptions{
+			End:         testDate.AddDate(0, 0, 5),
+			OverlapDays: 1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, result.Added, 1)
+		require.Equal(t, f.windows, [][2]time.Time{{testDate, testDate.AddDate(0, 0, 5)}})
+	})
+}
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+)
+
+const (
+	DefaultOverlapDays = 7
+	DefaultWindowDays  = 90
+)
+
+// Fetcher is implemented by inter.Banking.
+type Fetcher interface {
+	Transactions(ctx context.Context, start, end time.Time) ([]inter.Transaction, error)
+	Balance(ctx context.Context, date time.Time) (inter.Balance, error)
+}
+
+type SyncOptions struct {
+	// Start is the first date fetched when the account was never synced.
+	Start time.Time
+	// End is the last date fetched, defaults to today.
+	End time.Time
+	// OverlapDays before the last synced date are fetched again to catch
+	// late postings.
+	OverlapDays int
+	// WindowDays is the maximum period fetched in a single request.
+	WindowDays int
+}
+
+type SyncResult struct {
+	From  time.Time
+	To    time.Time
+	Added int
+}
+
+// Sync fetches the transactions since the last synchronized date, saving
+// the account after each window so an interrupted sync resumes from where
+// it stopped. Daily balances of the period are reconstructed from the
+// balance at the end date.
+func (s *Store) Sync(ctx context.Context, account string, f Fetcher, opts SyncOptions) (SyncResult, error) {
+	if opts.OverlapDays <= 0 {
+		opts.OverlapDays = DefaultOverlapDays
+	}
+
+	if opts.WindowDays <= 0 {
+		opts.WindowDays = DefaultWindowDays
+	}
+
+	if opts.End.IsZero() {
+		opts.End = time.Now()
+	}
+
+	a, err := s.Load(account)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	var from time.Time
+
+	if a.LastSynced.IsZero() {
+		if opts.Start.IsZero() {
+			return SyncResult{}, errors.New("start date is required on the first sync")
+		}
+
+		from = truncateDay(opts.Start)
+	} else {
+		from = a.LastSynced.AddDate(0, 0, -opts.OverlapDays)
+	}
+
+	to := truncateDay(opts.End)
+	if to.Before(from) {
+		return SyncResult{}, errors.New("end date is before the start date")
+	}
+
+	result := SyncResult{From: from, To: to}
+
+	for start := from; !start.After(to); start = start.AddDate(0, 0, opts.WindowDays) {
+		end := start.AddDate(0, 0, opts.WindowDays-1)
+		if end.After(to) {
+			end = to
+		}
+
+		transactions, err := f.Transactions(ctx, start, end)
+		if err != nil {
+			return result, err
+		}
+
+		result.Added += a.Merge(transactions)
+
+		if end.After(a.LastSynced) {
+			a.LastSynced = end
+		}
+
+		if err := s.Save(account, a); err != nil {
+			return result, err
+		}
+	}
+
+	closing, err := f.Balance(ctx, to)
+	if err != nil {
+		return result, err
+	}
+
+	a.setDailyBalances(from, to, closing)
+
+	return result, s.Save(account, a)
+}
+
+// Only the available balance is reconstructed for past days, other fields
+// are kept from the balance at the end date.
+func (a *Account) setDailyBalances(from, to time.Time, closing inter.Balance) {
+	if a.Balances == nil {
+		a.Balances = make(map[string]inter.Balance)
+	}
+
+	statement := inter.NewStatement(from, to, closing, a.Between(from, to))
+
+	daily := make(map[string]float32, len(statement.Entries))
+	for _, v := range statement.Entries {
+		daily[v.Date.Format(time.DateOnly)] = v.Balance
+	}
+
+	available := statement.OpeningBalance
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+
+		if v, ok := daily[key]; ok {
+			available = v
+		}
+
+		b := closing
+		b.Available = available
+		a.Balances[key] = b
+	}
+}
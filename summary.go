@@ -0,0 +1,137 @@
+package inter
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type Period int
+
+const (
+	DailyPeriod = Period(iota + 1)
+	WeeklyPeriod
+	MonthlyPeriod
+)
+
+func (p Period) String() string {
+	switch p {
+	case DailyPeriod:
+		return "day"
+	case WeeklyPeriod:
+		return "week"
+	case MonthlyPeriod:
+		return "month"
+	}
+
+	return "invalid"
+}
+
+// Key returns the period containing the date, formatted so that keys sort
+// chronologically: 2006-01-02 for days, 2006-W01 for ISO weeks and 2006-01
+// for months.
+func (p Period) Key(date time.Time) string {
+	switch p {
+	case DailyPeriod:
+		return date.Format(time.DateOnly)
+	case WeeklyPeriod:
+		year, week := date.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case MonthlyPeriod:
+		return date.Format("2006-01")
+	}
+
+	return ""
+}
+
+type Summary struct {
+	Group string
+
+	Credits int
+	Debits  int
+
+	Inflow  float32
+	Outflow float32
+	Net     float32
+}
+
+func (s Summary) Count() int {
+	return s.Credits + s.Debits
+}
+
+// Summarize aggregates the transactions by the group returned by key,
+// returning the summaries sorted by group.
+func Summarize(transactions []Transaction, key func(Transaction) string) []Summary {
+	type totals struct {
+		credits, debits int
+		inflow, outflow int64
+	}
+
+	groups := make(map[string]*totals)
+
+	for _, v := range transactions {
+		k := key(v)
+
+		g, ok := groups[k]
+		if !ok {
+			g = &totals{}
+			groups[k] = g
+		}
+
+		switch v.Operation {
+		case CreditTransactionOperation:
+			g.credits++
+			g.inflow += Cents(v.Value)
+		case DebitTransactionOperation:
+			g.debits++
+			g.outflow += Cents(v.Value)
+		}
+	}
+
+	summaries := make([]Summary, 0, len(groups))
+
+	for k, g := range groups {
+		summaries = append(summaries, Summary{
+			Group:   k,
+			Credits: g.credits,
+			Debits:  g.debits,
+			Inflow:  FromCents(g.inflow),
+			Outflow: FromCents(g.outflow),
+			Net:     FromCents(g.inflow - g.outflow),
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Group < summaries[j].Group
+	})
+
+	return summaries
+}
+
+func SummarizeByPeriod(transactions []Transaction, period Period) []Summary {
+	return Summarize(transactions, func(t Transaction) string {
+		return period.Key(t.Date)
+	})
+}
+
+func SummarizeByType(transactions []Transaction) []Summary {
+	return Summarize(transactions, func(t Transaction) string {
+		return t.Type.String()
+	})
+}
+
+func SummarizeByOperation(transactions []Transaction) []Summary {
+	return Summarize(transactions, func(t Transaction) string {
+		return t.Operation.String()
+	})
+}
+
+// Total aggregates all transactions in a single summary.
+func Total(transactions []Transaction) Summary {
+	s := Summarize(transactions, func(Transaction) string { return "total" })
+	if len(s) == 0 {
+		return Summary{Group: "total"}
+	}
+
+	return s[0]
+}
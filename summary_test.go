@@ -0,0 +1,59 @@
+package inter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriodKey(t *testing.T) {
+	date := time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, DailyPeriod.Key(date), "2022-01-02")
+	require.Equal(t, WeeklyPeriod.Key(date), "2021-W52")
+	require.Equal(t, MonthlyPeriod.Key(date), "2022-01")
+}
+
+func TestSummarize(t *testing.T) {
+	transactions := filterTestTransactions()
+
+	t.Run("returns no summaries without transactions", func(t *testing.T) {
+		require.Empty(t, SummarizeByType(nil))
+		require.Equal(t, Total(nil), Summary{Group: "total"})
+	})
+
+	t.Run("summarizes by period", func(t *testing.T) {
+		want := []Summary{
+			{Group: "2022-02-02", Debits: 1, Outflow: 50.5, Net: -50.5},
+			{Group: "2022-02-03", Credits: 1, Inflow: 100, Net: 100},
+			{Group: "2022-02-04", Debits: 1, Outflow: 10, Net: -10},
+		}
+
+		require.Equal(t, SummarizeByPeriod(transactions, DailyPeriod), want)
+	})
+
+	t.Run("summarizes by type", func(t *testing.T) {
+		want := []Summary{
+			{Group: "pagamento", Debits: 1, Outflow: 50.5, Net: -50.5},
+			{Group: "pix", Credits: 1, Debits: 1, Inflow: 100, Outflow: 10, Net: 90},
+		}
+
+		require.Equal(t, SummarizeByType(transactions), want)
+	})
+
+	t.Run("summarizes by operation", func(t *testing.T) {
+		want := []Summary{
+			{Group: "credit", Credits: 1, Inflow: 100, Net: 100},
+			{Group: "debit", Debits: 2, Outflow: 60.5, Net: -60.5},
+		}
+
+		require.Equal(t, SummarizeByOperation(transactions), want)
+	})
+
+	t.Run("summarizes everything", func(t *testing.T) {
+		got := Total(transactions)
+		require.Equal(t, got.Count(), 3)
+		require.Equal(t, got.Net, float32(39.5))
+	})
+}
@@ -1,9 +1,17 @@
 package inter
 
 import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 )
 
+// TokenExpiryMargin is the usual margin given to ValidFor, so tokens do
+// not expire while a request is in flight.
+const TokenExpiryMargin = time.Minute
+
 type Token struct {
 	Data      string
 	Type      string
@@ -12,9 +20,106 @@ type Token struct {
 }
 
 func (t Token) Valid() bool {
-	return time.Now().Before(t.ExpiresAt)
+	return t.ValidAt(time.Now())
+}
+
+// ValidAt reports whether the token is not expired at the given time, so
+// callers may use their own clock.
+func (t Token) ValidAt(now time.Time) bool {
+	return now.Before(t.ExpiresAt)
+}
+
+// ValidFor reports whether the token is still valid the margin after now.
+func (t Token) ValidFor(now time.Time, margin time.Duration) bool {
+	return t.ValidAt(now.Add(margin))
 }
 
 func TokenFromString(s string) Token {
 	return Token{Data: s}
 }
+
+// redacted keeps only the first characters of the token data, enough to
+// tell tokens apart.
+func (t Token) redacted() string {
+	if len(t.Data) <= 4 {
+		return "****"
+	}
+
+	return t.Data[:4] + "****"
+}
+
+// String describes the token without its data, so it is safe to print and
+// log.
+func (t Token) String() string {
+	expires := "unknown"
+	if !t.ExpiresAt.IsZero() {
+		expires = t.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("%s token %s, scopes %s, expires %s", t.Type, t.redacted(),
+		strings.Join(t.Scopes, " "), expires)
+}
+
+func (t Token) GoString() string {
+	return "inter.Token(" + t.String() + ")"
+}
+
+func (t Token) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("data", t.redacted()),
+		slog.String("type", t.Type),
+		slog.Any("scopes", t.Scopes),
+		slog.Time("expires_at", t.ExpiresAt),
+	)
+}
+
+type jsonToken struct {
+	Data      string   `json:"data"`
+	Type      string   `json:"type"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at,omitempty"`
+}
+
+// MarshalJSON encodes the token with its data, so it can be saved and
+// loaded again by UnmarshalJSON.
+func (t Token) MarshalJSON() ([]byte, error) {
+	tmp := jsonToken{
+		Data:   t.Data,
+		Type:   t.Type,
+		Scopes: t.Scopes,
+	}
+
+	if !t.ExpiresAt.IsZero() {
+		tmp.ExpiresAt = t.ExpiresAt.Format(time.RFC3339Nano)
+	}
+
+	return json.Marshal(tmp)
+}
+
+func (t *Token) UnmarshalJSON(data []byte) error {
+	var tmp jsonToken
+
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	var expiresAt time.Time
+
+	if tmp.ExpiresAt != "" {
+		var err error
+
+		expiresAt, err = time.Parse(time.RFC3339Nano, tmp.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("invalid token expiration: %w", err)
+		}
+	}
+
+	*t = Token{
+		Data:      tmp.Data,
+		Type:      tmp.Type,
+		Scopes:    tmp.Scopes,
+		ExpiresAt: expiresAt,
+	}
+
+	return nil
+}
@@ -1,6 +1,10 @@
 package inter
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -28,4 +32,95 @@ func TestToken(t *testing.T) {
 		}
 		require.False(t, token.Valid())
 	})
+
+	t.Run("returns validity at the given time and margin", func(t *testing.T) {
+		now := time.Date(2022, 2, 2, 2, 22, 22, 0, time.UTC)
+		token := Token{ExpiresAt: now.Add(time.Minute)}
+
+		require.True(t, token.ValidAt(now))
+		require.False(t, token.ValidAt(now.Add(time.Minute)))
+
+		token.ExpiresAt = now.Add(30 * time.Second)
+		require.True(t, token.ValidFor(now, 0))
+		require.False(t, token.ValidFor(now, TokenExpiryMargin))
+	})
+}
+
+func TestTokenJSON(t *testing.T) {
+	t.Run("round-trips expiration and scopes", func(t *testing.T) {
+		token := Token{
+			Data:      "a1200a94-b847-4cda-a510-cc0b9c7182d4",
+			Type:      "Bearer",
+			Scopes:    []string{"extrato.read", "pix.read"},
+			ExpiresAt: time.Date(2022, 2, 2, 2, 22, 22, 0, time.FixedZone("", -3*60*60)),
+		}
+
+		data, err := json.Marshal(token)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"data": "a1200a94-b847-4cda-a510-cc0b9c7182d4",
+			"type": "Bearer",
+			"scopes": ["extrato.read", "pix.read"],
+			"expires_at": "2022-02-02T02:22:22-03:00"
+		}`, string(data))
+
+		var decoded Token
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, token.Data, decoded.Data)
+		require.Equal(t, token.Scopes, decoded.Scopes)
+		require.True(t, token.ExpiresAt.Equal(decoded.ExpiresAt))
+	})
+
+	t.Run("keeps the expiration fraction of second", func(t *testing.T) {
+		token := Token{
+			Data:      "token-data",
+			ExpiresAt: time.Date(2022, 2, 2, 2, 22, 22, 123456789, time.UTC),
+		}
+
+		data, err := json.Marshal(token)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"expires_at":"2022-02-02T02:22:22.123456789Z"`)
+
+		var decoded Token
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.True(t, token.ExpiresAt.Equal(decoded.ExpiresAt))
+	})
+
+	t.Run("omits unknown expiration", func(t *testing.T) {
+		data, err := json.Marshal(TokenFromString("token-data"))
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "expires_at")
+
+		var decoded Token
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.True(t, decoded.ExpiresAt.IsZero())
+	})
+
+	t.Run("returns an error on invalid expiration", func(t *testing.T) {
+		var decoded Token
+		require.Error(t, json.Unmarshal([]byte(`{"expires_at": "tomorrow"}`), &decoded))
+	})
+}
+
+func TestTokenRedaction(t *testing.T) {
+	token := Token{
+		Data:      "a1200a94-b847-4cda-a510-cc0b9c7182d4",
+		Type:      "Bearer",
+		Scopes:    []string{"extrato.read"},
+		ExpiresAt: time.Date(2022, 2, 2, 2, 22, 22, 0, time.UTC),
+	}
+
+	require.Equal(t, "Bearer token a120****, scopes extrato.read, expires 2022-02-02T02:22:22Z", token.String())
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		require.NotContains(t, fmt.Sprintf(format, token), token.Data, format)
+	}
+
+	var buf bytes.Buffer
+
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("authorized", "token", token)
+	require.Contains(t, buf.String(), "token.data=a120****")
+	require.NotContains(t, buf.String(), token.Data)
+
+	require.NotContains(t, TokenFromString("abc").String(), "abc")
 }
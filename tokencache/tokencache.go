@@ -0,0 +1,176 @@
+package tokencache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/internal/filelock"
+)
+
+// ErrNotFound is returned when there is no valid cached token and no way to
+// authorize a new one.
+var ErrNotFound = errors.New("no valid cached token")
+
+// Key identifies a cached token. Scopes are compared as a set.
+type Key struct {
+	ClientID string
+	Scopes   []string
+	Account  string
+}
+
+// ID returns a stable identifier of the key, used as the cache file name.
+func (k Key) ID() string {
+	scopes := make([]string, 0, len(k.Scopes))
+	for _, v := range k.Scopes {
+		if v = strings.TrimSpace(v); v != "" {
+			scopes = append(scopes, v)
+		}
+	}
+
+	sort.Strings(scopes)
+
+	sum := sha256.Sum256([]byte(k.ClientID + "\n" + strings.Join(scopes, " ") + "\n" + k.Account))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// Cache keeps tokens as one file per key inside a directory, readable only
+// by the owner. Each key is locked while read or written, so concurrent
+// processes do not authorize twice nor see partially written files.
+type Cache struct {
+	dir string
+	now func() time.Time
+}
+
+// DefaultDir returns the tokens directory inside the user cache directory.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "go-inter", "tokens"), nil
+}
+
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &Cache{dir: dir, now: time.Now}, nil
+}
+
+func (c *Cache) path(k Key) string {
+	return filepath.Join(c.dir, k.ID())
+}
+
+// Load returns the cached token, valid or not, and false if there is none.
+func (c *Cache) Load(k Key) (inter.Token, bool, error) {
+	unlock, err := filelock.Lock(context.Background(), c.path(k)+".lock")
+	if err != nil {
+		return inter.Token{}, false, err
+	}
+	defer unlock()
+
+	return c.load(k)
+}
+
+// Save writes the token to the cache.
+func (c *Cache) Save(k Key, t inter.Token) error {
+	unlock, err := filelock.Lock(context.Background(), c.path(k)+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return c.save(k, t)
+}
+
+// Token returns the cached token while it is valid for at least a minute.
+// Otherwise, it calls authorize and caches the new token, returning
+// ErrNotFound when authorize is nil. The key stays locked during the
+// authorization, so processes sharing the cache wait for the new token
+// instead of authorizing again.
+func (c *Cache) Token(ctx context.Context, k Key, authorize func(ctx context.Context) (inter.Token, error)) (inter.Token, error) {
+	unlock, err := filelock.Lock(ctx, c.path(k)+".lock")
+	if err != nil {
+		return inter.Token{}, err
+	}
+	defer unlock()
+
+	t, ok, err := c.load(k)
+	if err != nil {
+		return inter.Token{}, err
+	}
+
+	if ok && t.ValidFor(c.now(), inter.TokenExpiryMargin) {
+		return t, nil
+	}
+
+	if authorize == nil {
+		return inter.Token{}, ErrNotFound
+	}
+
+	t, err = authorize(ctx)
+	if err != nil {
+		return inter.Token{}, err
+	}
+
+	return t, c.save(k, t)
+}
+
+func (c *Cache) load(k Key) (inter.Token, bool, error) {
+	path := c.path(k) + ".json"
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return inter.Token{}, false, nil
+	} else if err != nil {
+		return inter.Token{}, false, err
+	}
+
+	var t inter.Token
+
+	if err := json.Unmarshal(data, &t); err != nil {
+		return inter.Token{}, false, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	return t, true, nil
+}
+
+// The token is written to a temporary file renamed over the previous one.
+// Temporary files are created with 0600 permissions.
+func (c *Cache) save(k Key, t inter.Token) error {
+	data, err := json.MarshalIndent(t, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), c.path(k)+".json")
+}
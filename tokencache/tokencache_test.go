@@ -0,0 +1,153 @@
+package tokencache
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = Key{
+	ClientID: "client",
+	Scopes:   []string{"extrato.read", "boleto-cobranca.read"},
+	Account:  "12345",
+}
+
+func testToken(expiresIn time.Duration) inter.Token {
+	return inter.Token{
+		Data:      "token",
+		Type:      "bearer",
+		Scopes:    []string{"extrato.read", "boleto-cobranca.read"},
+		ExpiresAt: time.Now().Add(expiresIn).Truncate(time.Second),
+	}
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves and loads tokens", func(t *testing.T) {
+		c, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		_, ok, err := c.Load(testKey)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		token := testToken(time.Hour)
+		require.NoError(t, c.Save(testKey, token))
+
+		got, ok, err := c.Load(Key{
+			ClientID: "client",
+			Scopes:   []string{"boleto-cobranca.read", "extrato.read"},
+			Account:  "12345",
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, token.Data, got.Data)
+		require.Equal(t, token.Scopes, got.Scopes)
+		require.True(t, token.ExpiresAt.Equal(got.ExpiresAt))
+
+		for _, k := range []Key{
+			{ClientID: "other", Scopes: testKey.Scopes, Account: "12345"},
+			{ClientID: "client", Scopes: []string{"extrato.read"}, Account: "12345"},
+			{ClientID: "client", Scopes: testKey.Scopes},
+		} {
+			_, ok, err := c.Load(k)
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+	})
+
+	t.Run("writes files readable only by the owner", func(t *testing.T) {
+		c, err := Open(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, c.Save(testKey, testToken(time.Hour)))
+
+		info, err := os.Stat(c.path(testKey) + ".json")
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("checks the expiry margin at the cache time", func(t *testing.T) {
+		c, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		require.NoError(t, c.Save(testKey, testToken(time.Hour)))
+
+		_, err = c.Token(ctx, testKey, nil)
+		require.NoError(t, err)
+
+		c.now = func() time.Time { return time.Now().Add(time.Hour - 30*time.Second) }
+
+		_, err = c.Token(ctx, testKey, nil)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("authorizes when the token is not valid", func(t *testing.T) {
+		c, err := Open(t.TempDir())
+		require.NoError(t, err)
+
+		_, err = c.Token(ctx, testKey, nil)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		require.NoError(t, c.Save(testKey, testToken(30*time.Second)))
+
+		_, err = c.Token(ctx, testKey, nil)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		calls := 0
+		authorize := func(ctx context.Context) (inter.Token, error) {
+			calls++
+			return testToken(time.Hour), nil
+		}
+
+		token, err := c.Token(ctx, testKey, authorize)
+		require.NoError(t, err)
+		require.Equal(t, "token", token.Data)
+		require.Equal(t, 1, calls)
+
+		_, err = c.Token(ctx, testKey, authorize)
+		require.NoError(t, err)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("authorizes once for concurrent callers", func(t *testing.T) {
+		dir := t.TempDir()
+
+		var (
+			mu    sync.Mutex
+			calls int
+			wg    sync.WaitGroup
+		)
+
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				// Each caller opens its own cache, as separate processes.
+				c, err := Open(dir)
+				require.NoError(t, err)
+
+				_, err = c.Token(ctx, testKey, func(ctx context.Context) (inter.Token, error) {
+					mu.Lock()
+					calls++
+					mu.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					return testToken(time.Hour), nil
+				})
+				require.NoError(t, err)
+			}()
+		}
+
+		wg.Wait()
+		require.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,61 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// PassphraseEnv is the environment variable read by ReadPassphrase, for
+// non interactive use.
+const PassphraseEnv = "INTER_VAULT_PASSPHRASE"
+
+// ReadPassphrase returns the passphrase from the environment, or prompts
+// for it on the terminal without echo.
+func ReadPassphrase(prompt string) (string, error) {
+	if v, ok := os.LookupEnv(PassphraseEnv); ok {
+		return v, nil
+	}
+
+	v, err := ReadSecret(prompt)
+	if err != nil {
+		return "", fmt.Errorf("could not read passphrase, %s is not set: %w", PassphraseEnv, err)
+	}
+
+	return v, nil
+}
+
+// ReadSecret prompts for a secret on the terminal without echo.
+func ReadSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(fd) {
+		return "", errors.New("standard input is not a terminal")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) == 0 {
+		return "", errors.New("empty input")
+	}
+
+	return string(data), nil
+}
+
+// OpenPrompt opens the vault with the passphrase read by ReadPassphrase.
+func OpenPrompt(path string) (*Vault, error) {
+	passphrase, err := ReadPassphrase("Vault passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(path, passphrase)
+}
@@ -0,0 +1,421 @@
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/certificate"
+	"github.com/agiacomolli/go-inter/internal/filelock"
+	"golang.org/x/crypto/argon2"
+)
+
+var (
+	ErrExists          = errors.New("vault already exists")
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted vault")
+	ErrNotFound        = errors.New("secret not found")
+)
+
+type Kind string
+
+const (
+	ClientSecretKind Kind = "client-secret"
+	PrivateKeyKind   Kind = "private-key"
+	TokenKind        Kind = "token"
+)
+
+func ParseKind(s string) (Kind, error) {
+	switch k := Kind(s); k {
+	case ClientSecretKind, PrivateKeyKind, TokenKind:
+		return k, nil
+	}
+
+	return "", fmt.Errorf("invalid secret kind %q", s)
+}
+
+// Argon2id parameters of new vaults, stored in the file so they can be
+// raised without breaking existing vaults.
+const (
+	defaultTime    = 3
+	defaultMemory  = 64 * 1024
+	defaultThreads = 4
+	keyLength      = 32
+	saltLength     = 16
+)
+
+const formatVersion = 1
+
+// DefaultPath returns the vault file inside the user configuration
+// directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "go-inter", "vault"), nil
+}
+
+type Secret struct {
+	Kind Kind
+	Name string
+	Data []byte
+}
+
+// Vault holds secrets encrypted with AES-GCM, using a key derived from a
+// passphrase with Argon2id. Secrets are identified by their kind and name,
+// and changes are written only by Save.
+type Vault struct {
+	path    string
+	header  header
+	key     []byte
+	secrets map[string]Secret
+
+	// changes holds the secrets set or removed, with nil values, since the
+	// vault was read, to be merged into the file by Save.
+	changes map[string]*Secret
+}
+
+type header struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+type file struct {
+	header
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type storedSecret struct {
+	Kind Kind   `json:"kind"`
+	Name string `json:"name"`
+	Data []byte `json:"data"`
+}
+
+// Create returns an empty vault, writing it to the path, which must not
+// exist.
+func Create(path, passphrase string) (*Vault, error) {
+	if _, err := os.Stat(path); err == nil {
+		return nil, ErrExists
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required")
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	v := &Vault{
+		path: path,
+		header: header{
+			Version: formatVersion,
+			KDF:     "argon2id",
+			Salt:    salt,
+			Time:    defaultTime,
+			Memory:  defaultMemory,
+			Threads: defaultThreads,
+		},
+		secrets: make(map[string]Secret),
+		changes: make(map[string]*Secret),
+	}
+
+	v.key = v.header.deriveKey(passphrase)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	return v, v.Save()
+}
+
+// Open decrypts the vault, returning ErrWrongPassphrase if the passphrase
+// does not match.
+func Open(path, passphrase string) (*Vault, error) {
+	f, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Vault{path: path, header: f.header, changes: make(map[string]*Secret)}
+	v.key = v.header.deriveKey(passphrase)
+
+	v.secrets, err = v.decrypt(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func readFile(path string) (file, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file{}, err
+	}
+
+	var f file
+
+	if err := json.Unmarshal(data, &f); err != nil {
+		return file{}, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	if f.Version != formatVersion || f.KDF != "argon2id" {
+		return file{}, fmt.Errorf("unsupported vault version %d with %q", f.Version, f.KDF)
+	}
+
+	return f, nil
+}
+
+// decrypt returns the secrets of the file, which must have been encrypted
+// with the vault key.
+func (v *Vault) decrypt(f file) (map[string]Secret, error) {
+	aead, err := newAEAD(v.key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, f.Nonce, f.Ciphertext, f.header.additionalData())
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	var stored []storedSecret
+
+	if err := json.Unmarshal(plaintext, &stored); err != nil {
+		return nil, fmt.Errorf("could not parse secrets: %w", err)
+	}
+
+	secrets := make(map[string]Secret, len(stored))
+	for _, s := range stored {
+		secrets[id(s.Kind, s.Name)] = Secret(s)
+	}
+
+	return secrets, nil
+}
+
+func (h header) deriveKey(passphrase string) []byte {
+	return argon2.IDKey([]byte(passphrase), h.Salt, h.Time, h.Memory, h.Threads, keyLength)
+}
+
+// The header is authenticated, so its parameters can not be changed
+// without the passphrase.
+func (h header) additionalData() []byte {
+	data, _ := json.Marshal(h)
+	return data
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Save writes the changes made since the vault was read. The file is locked
+// and read again, so the secrets saved meanwhile by other processes are
+// kept, and the merged secrets are encrypted with a new nonce into a
+// temporary file renamed over the vault.
+func (v *Vault) Save() error {
+	unlock, err := filelock.Lock(context.Background(), v.path+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	secrets := make(map[string]Secret)
+
+	if f, err := readFile(v.path); err == nil {
+		if secrets, err = v.decrypt(f); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	for k, s := range v.changes {
+		if s == nil {
+			delete(secrets, k)
+		} else {
+			secrets[k] = *s
+		}
+	}
+
+	if err := v.write(secrets); err != nil {
+		return err
+	}
+
+	v.secrets = secrets
+	v.changes = make(map[string]*Secret)
+
+	return nil
+}
+
+func (v *Vault) write(secrets map[string]Secret) error {
+	stored := make([]storedSecret, 0, len(secrets))
+	for _, s := range sortSecrets(secrets) {
+		stored = append(stored, storedSecret(s))
+	}
+
+	plaintext, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(v.key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(file{
+		header:     v.header,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, v.header.additionalData()),
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(v.path), ".vault-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), v.path)
+}
+
+func id(kind Kind, name string) string {
+	return string(kind) + "/" + name
+}
+
+func (v *Vault) Get(kind Kind, name string) (Secret, bool) {
+	s, ok := v.secrets[id(kind, name)]
+	return s, ok
+}
+
+func (v *Vault) Set(kind Kind, name string, data []byte) {
+	s := Secret{Kind: kind, Name: name, Data: data}
+
+	v.secrets[id(kind, name)] = s
+	v.changes[id(kind, name)] = &s
+}
+
+// Remove deletes the secret, returning false if it does not exist.
+func (v *Vault) Remove(kind Kind, name string) bool {
+	if _, ok := v.secrets[id(kind, name)]; !ok {
+		return false
+	}
+
+	delete(v.secrets, id(kind, name))
+	v.changes[id(kind, name)] = nil
+
+	return true
+}
+
+// List returns the secrets sorted by kind and name.
+func (v *Vault) List() []Secret {
+	return sortSecrets(v.secrets)
+}
+
+func sortSecrets(m map[string]Secret) []Secret {
+	secrets := make([]Secret, 0, len(m))
+	for _, s := range m {
+		secrets = append(secrets, s)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return id(secrets[i].Kind, secrets[i].Name) < id(secrets[j].Kind, secrets[j].Name)
+	})
+
+	return secrets
+}
+
+// ClientSecret returns the secret of the client identification.
+func (v *Vault) ClientSecret(clientID string) (string, error) {
+	s, ok := v.Get(ClientSecretKind, clientID)
+	if !ok {
+		return "", fmt.Errorf("client secret of %q: %w", clientID, ErrNotFound)
+	}
+
+	return strings.TrimSpace(string(s.Data)), nil
+}
+
+// LoadX509KeyPair reads the certificate from the file and its PEM encoded
+// private key from the vault.
+func (v *Vault) LoadX509KeyPair(certFile, keyName string) (tls.Certificate, error) {
+	s, ok := v.Get(PrivateKeyKind, keyName)
+	if !ok {
+		return tls.Certificate{}, fmt.Errorf("private key %q: %w", keyName, ErrNotFound)
+	}
+
+	cert, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return certificate.ParsePEM(cert, s.Data)
+}
+
+// Token returns the token cached with the name, and false if there is none.
+func (v *Vault) Token(name string) (inter.Token, bool, error) {
+	s, ok := v.Get(TokenKind, name)
+	if !ok {
+		return inter.Token{}, false, nil
+	}
+
+	var t inter.Token
+
+	if err := json.Unmarshal(s.Data, &t); err != nil {
+		return inter.Token{}, false, fmt.Errorf("could not parse token %q: %w", name, err)
+	}
+
+	return t, true, nil
+}
+
+func (v *Vault) SetToken(name string, t inter.Token) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	v.Set(TokenKind, name, data)
+
+	return nil
+}
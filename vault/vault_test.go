@@ -0,0 +1,212 @@
+package vault
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/stretchr/testify/require"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+func TestVault(t *testing.T) {
+	t.Run("creates and opens vaults", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "go-inter", "vault")
+
+		v, err := Create(path, testPassphrase)
+		require.NoError(t, err)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		v.Set(ClientSecretKind, "client", []byte("s3cr3t\n"))
+		v.Set(PrivateKeyKind, "cert", []byte("key"))
+		require.NoError(t, v.Save())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "s3cr3t")
+
+		v, err = Open(path, testPassphrase)
+		require.NoError(t, err)
+
+		secret, err := v.ClientSecret("client")
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", secret)
+
+		require.Equal(t, []Secret{
+			{Kind: ClientSecretKind, Name: "client", Data: []byte("s3cr3t\n")},
+			{Kind: PrivateKeyKind, Name: "cert", Data: []byte("key")},
+		}, v.List())
+
+		require.True(t, v.Remove(PrivateKeyKind, "cert"))
+		require.False(t, v.Remove(PrivateKeyKind, "cert"))
+		require.NoError(t, v.Save())
+
+		v, err = Open(path, testPassphrase)
+		require.NoError(t, err)
+		require.Len(t, v.List(), 1)
+
+		_, err = v.ClientSecret("other")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("does not overwrite vaults", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "vault")
+
+		_, err := Create(path, testPassphrase)
+		require.NoError(t, err)
+
+		_, err = Create(path, testPassphrase)
+		require.ErrorIs(t, err, ErrExists)
+	})
+
+	t.Run("rejects wrong passphrases and tampering", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "vault")
+
+		_, err := Create(path, testPassphrase)
+		require.NoError(t, err)
+
+		_, err = Open(path, "wrong")
+		require.ErrorIs(t, err, ErrWrongPassphrase)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var f map[string]any
+		require.NoError(t, json.Unmarshal(data, &f))
+		f["time"] = 1
+
+		data, err = json.Marshal(f)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+
+		_, err = Open(path, testPassphrase)
+		require.ErrorIs(t, err, ErrWrongPassphrase)
+	})
+
+	t.Run("keeps the changes saved by other handles", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "vault")
+
+		v, err := Create(path, testPassphrase)
+		require.NoError(t, err)
+
+		v.Set(ClientSecretKind, "client", []byte("s3cr3t"))
+		v.Set(PrivateKeyKind, "cert", []byte("key"))
+		require.NoError(t, v.Save())
+
+		// A long running command holds the vault read before the secrets
+		// were changed by another one.
+		stale, err := Open(path, testPassphrase)
+		require.NoError(t, err)
+
+		v.Set(ClientSecretKind, "other", []byte("0th3r"))
+		require.True(t, v.Remove(PrivateKeyKind, "cert"))
+		require.NoError(t, v.Save())
+
+		require.NoError(t, stale.SetToken("key", inter.Token{Data: "a1200a94"}))
+		require.NoError(t, stale.Save())
+
+		var names []string
+		for _, s := range stale.List() {
+			names = append(names, id(s.Kind, s.Name))
+		}
+
+		require.Equal(t, []string{"client-secret/client", "client-secret/other", "token/key"}, names)
+
+		v.Set(ClientSecretKind, "client", []byte("n3w"))
+		require.NoError(t, v.Save())
+
+		v, err = Open(path, testPassphrase)
+		require.NoError(t, err)
+
+		secret, err := v.ClientSecret("client")
+		require.NoError(t, err)
+		require.Equal(t, "n3w", secret)
+
+		_, ok, err := v.Token("key")
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("caches tokens", func(t *testing.T) {
+		v, err := Create(filepath.Join(t.TempDir(), "vault"), testPassphrase)
+		require.NoError(t, err)
+
+		_, ok, err := v.Token("key")
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		token := inter.Token{
+			Data:      "token",
+			Type:      "bearer",
+			Scopes:    []string{"extrato.read"},
+			ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+		require.NoError(t, v.SetToken("key", token))
+
+		got, ok, err := v.Token("key")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, token.Data, got.Data)
+		require.True(t, token.ExpiresAt.Equal(got.ExpiresAt))
+	})
+
+	t.Run("loads key pairs", func(t *testing.T) {
+		dir := t.TempDir()
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "test"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+
+		certFile := filepath.Join(dir, "cert.crt")
+		require.NoError(t, os.WriteFile(certFile,
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+
+		keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		v, err := Create(filepath.Join(dir, "vault"), testPassphrase)
+		require.NoError(t, err)
+
+		_, err = v.LoadX509KeyPair(certFile, "cert")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		v.Set(PrivateKeyKind, "cert",
+			pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+
+		cert, err := v.LoadX509KeyPair(certFile, "cert")
+		require.NoError(t, err)
+		require.Len(t, cert.Certificate, 1)
+	})
+}
+
+func TestParseKind(t *testing.T) {
+	k, err := ParseKind("private-key")
+	require.NoError(t, err)
+	require.Equal(t, PrivateKeyKind, k)
+
+	_, err = ParseKind("password")
+	require.Error(t, err)
+}
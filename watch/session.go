@@ -0,0 +1,183 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/notify"
+)
+
+const (
+	DefaultMaxBackoff   = 15 * time.Minute
+	DefaultExpiryMargin = time.Minute
+)
+
+// ErrSessionExpired is returned by connectors that can not issue a new
+// session, stopping the watcher.
+var ErrSessionExpired = errors.New("session expired")
+
+// Fetcher is implemented by inter.Banking.
+type Fetcher interface {
+	Transactions(ctx context.Context, start, end time.Time) ([]inter.Transaction, error)
+	Balance(ctx context.Context, date time.Time) (inter.Balance, error)
+}
+
+type Session struct {
+	// Fetcher returns the data source of the account.
+	Fetcher func(account string) Fetcher
+	// ExpiresAt is the expiration of the session token, zero if unknown.
+	ExpiresAt time.Time
+}
+
+// Connector issues the sessions used to poll. It is called again when the
+// session is about to expire or when the API rejects its token.
+type Connector func(ctx context.Context) (Session, error)
+
+// Sessions keeps the current session of a connector.
+type Sessions struct {
+	connect Connector
+	margin  time.Duration
+	current *Session
+}
+
+// NewSessions returns the sessions of the connector, connecting again when
+// the current session expires within the margin.
+func NewSessions(connect Connector, margin time.Duration) *Sessions {
+	if margin <= 0 {
+		margin = DefaultExpiryMargin
+	}
+
+	return &Sessions{connect: connect, margin: margin}
+}
+
+func (s *Sessions) session(ctx context.Context, now time.Time) (*Session, error) {
+	if s.current != nil {
+		expiresAt := s.current.ExpiresAt
+		if expiresAt.IsZero() || now.Add(s.margin).Before(expiresAt) {
+			return s.current, nil
+		}
+	}
+
+	tmp, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.current = &tmp
+
+	return s.current, nil
+}
+
+// ForEach calls poll with the fetcher of each account, sending the errors
+// to the sink and returning the first one. Polling stops on the first rate
+// limited or unauthorized request, since the next accounts would fail as
+// well, and the session is renewed after unauthorized requests.
+func (s *Sessions) ForEach(ctx context.Context, now time.Time, accounts []string, sink notify.Sink, onError func(error),
+	poll func(ctx context.Context, account string, f Fetcher) error) error {
+	session, err := s.session(ctx, now)
+	if err != nil {
+		if !errors.Is(err, ErrSessionExpired) {
+			notifyError(ctx, now, sink, onError, "", fmt.Errorf("could not connect: %w", err))
+		}
+
+		return err
+	}
+
+	var first error
+
+	for _, account := range accounts {
+		err := poll(ctx, account, session.Fetcher(account))
+		if err == nil {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		notifyError(ctx, now, sink, onError, account, err)
+
+		if first == nil {
+			first = err
+		}
+
+		var apiErr *inter.APIError
+		if errors.As(err, &apiErr) {
+			if apiErr.Unauthorized() {
+				s.current = nil
+				break
+			}
+
+			if apiErr.RateLimited() {
+				break
+			}
+		}
+	}
+
+	return first
+}
+
+func notifyError(ctx context.Context, now time.Time, sink notify.Sink, onError func(error), account string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	e := notify.Event{
+		Type:    notify.ErrorEvent,
+		Account: account,
+		Time:    now,
+		Message: err.Error(),
+	}
+
+	if serr := sink.Notify(ctx, e); serr != nil {
+		onError(err)
+		onError(fmt.Errorf("could not notify error: %w", serr))
+	}
+}
+
+// Run calls poll on every interval until the context is done or the
+// session can not be renewed. After errors polling is retried with an
+// exponential backoff, or after the delay requested by the API when rate
+// limited.
+func Run(ctx context.Context, interval, maxBackoff time.Duration, poll func(ctx context.Context) error) error {
+	if maxBackoff < interval {
+		maxBackoff = interval
+	}
+
+	backoff := interval
+
+	for {
+		delay := interval
+
+		if err := poll(ctx); errors.Is(err, ErrSessionExpired) {
+			return err
+		} else if ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+
+			delay = backoff
+
+			var apiErr *inter.APIError
+			if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+				delay = apiErr.RetryAfter
+			}
+		} else {
+			backoff = interval
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/notify"
+)
+
+const (
+	DefaultInterval     = time.Minute
+	DefaultLookbackDays = 2
+)
+
+type Options struct {
+	// Accounts polled, the account bound to the session is polled when
+	// empty.
+	Accounts []string
+	Interval time.Duration
+	// LookbackDays before today are fetched on every poll, so late
+	// postings are still detected.
+	LookbackDays int
+	// MaxBackoff is the maximum delay between polls after errors.
+	MaxBackoff time.Duration
+	// ExpiryMargin before the session expiration to connect again.
+	ExpiryMargin time.Duration
+	// OnError is called with the errors that could not be sent to the
+	// sink.
+	OnError func(err error)
+}
+
+// Watcher polls the accounts transactions, sending an event for each
+// transaction not seen before. The first poll of each account only records
+// the existing transactions.
+type Watcher struct {
+	sessions *Sessions
+	sink     notify.Sink
+	opts     Options
+	now      func() time.Time
+
+	accounts map[string]*accountState
+}
+
+type accountState struct {
+	primed bool
+	// Dates of the seen transactions, indexed by their identifiers.
+	seen map[string]time.Time
+}
+
+func New(connect Connector, sink notify.Sink, opts Options) *Watcher {
+	if len(opts.Accounts) == 0 {
+		opts.Accounts = []string{""}
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+
+	if opts.LookbackDays <= 0 {
+		opts.LookbackDays = DefaultLookbackDays
+	}
+
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if opts.OnError == nil {
+		opts.OnError = func(error) {}
+	}
+
+	w := &Watcher{
+		sessions: NewSessions(connect, opts.ExpiryMargin),
+		sink:     sink,
+		opts:     opts,
+		now:      time.Now,
+		accounts: make(map[string]*accountState, len(opts.Accounts)),
+	}
+
+	for _, v := range opts.Accounts {
+		w.accounts[v] = &accountState{seen: make(map[string]time.Time)}
+	}
+
+	return w
+}
+
+// Run polls until the context is done, as the Run function.
+func (w *Watcher) Run(ctx context.Context) error {
+	return Run(ctx, w.opts.Interval, w.opts.MaxBackoff, w.Poll)
+}
+
+// Poll fetches the transactions of every account once, as the
+// Sessions.ForEach method.
+func (w *Watcher) Poll(ctx context.Context) error {
+	return w.sessions.ForEach(ctx, w.now(), w.opts.Accounts, w.sink, w.opts.OnError, w.pollAccount)
+}
+
+func (w *Watcher) pollAccount(ctx context.Context, account string, f Fetcher) error {
+	state := w.accounts[account]
+
+	now := w.now()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -w.opts.LookbackDays)
+
+	transactions, err := f.Transactions(ctx, start, end)
+	if err != nil {
+		return err
+	}
+
+	for i, id := range inter.TransactionIDs(transactions) {
+		if _, ok := state.seen[id]; ok {
+			continue
+		}
+
+		state.seen[id] = transactions[i].Date
+
+		if !state.primed {
+			continue
+		}
+
+		err := w.sink.Notify(ctx, notify.Event{
+			Type:        notify.TransactionEvent,
+			Account:     account,
+			Time:        now,
+			Transaction: notify.NewTransaction(id, transactions[i]),
+		})
+		if err != nil {
+			w.opts.OnError(fmt.Errorf("could not notify transaction %s: %w", id, err))
+		}
+	}
+
+	state.primed = true
+
+	// Identifiers depend on the other transactions of the same day, so they
+	// are only kept while the day is fetched.
+	for id, date := range state.seen {
+		if date.Before(start) {
+			delete(state.seen, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/agiacomolli/go-inter"
+	"github.com/agiacomolli/go-inter/notify"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2022, time.February, 2, 10, 0, 0, 0, time.UTC)
+
+type fakeFetcher struct {
+	transactions []inter.Transaction
+	windows      [][2]time.Time
+	err          error
+}
+
+func (f *fakeFetcher) Transactions(ctx context.Context, start, end time.Time) ([]inter.Transaction, error) {
+	f.windows = append(f.windows, [2]time.Time{start, end})
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.transactions, nil
+}
+
+func (f *fakeFetcher) Balance(ctx context.Context, date time.Time) (inter.Balance, error) {
+	return inter.Balance{}, nil
+}
+
+type recorder struct {
+	events []notify.Event
+}
+
+func (r *recorder) Notify(ctx context.Context, e notify.Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func testTransaction(day int, value float32, title string) inter.Transaction {
+	return inter.Transaction{
+		Date:      time.Date(2022, time.February, day, 0, 0, 0, 0, time.UTC),
+		Type:      inter.PixTransactionType,
+		Operation: inter.CreditTransactionOperation,
+		Value:     value,
+		Title:     title,
+	}
+}
+
+func newTestWatcher(fetchers map[string]*fakeFetcher, sink notify.Sink, opts Options) (*Watcher, *int) {
+	connects := 0
+
+	w := New(func(ctx context.Context) (Session, error) {
+		connects++
+
+		return Session{
+			Fetcher: func(account string) Fetcher {
+				return fetchers[account]
+			},
+			ExpiresAt: testNow.Add(time.Hour),
+		}, nil
+	}, sink, opts)
+
+	w.now = func() time.Time { return testNow }
+
+	return w, &connects
+}
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("notifies new transactions after the first poll", func(t *testing.T) {
+		f := &fakeFetcher{transactions: []inter.Transaction{
+			testTransaction(1, 10, "Pix recebido"),
+		}}
+		r := &recorder{}
+
+		w, _ := newTestWatcher(map[string]*fakeFetcher{"12345": f}, r,
+			Options{Accounts: []string{"12345"}})
+
+		require.NoError(t, w.Poll(ctx))
+		require.Empty(t, r.events)
+
+		require.Equal(t, [2]time.Time{
+			time.Date(2022, time.January, 31, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC),
+		}, f.windows[0])
+
+		f.transactions = append(f.transactions,
+			testTransaction(2, 20, "Pix recebido"),
+			testTransaction(2, 20, "Pix recebido"))
+
+		require.NoError(t, w.Poll(ctx))
+		require.Len(t, r.events, 2)
+
+		for _, e := range r.events {
+			require.Equal(t, notify.TransactionEvent, e.Type)
+			require.Equal(t, "12345", e.Account)
+			require.Equal(t, testNow, e.Time)
+			require.Equal(t, "20.00", e.Transaction.Value)
+		}
+
+		require.NotEqual(t, r.events[0].Transaction.ID, r.events[1].Transaction.ID)
+
+		require.NoError(t, w.Poll(ctx))
+		require.Len(t, r.events, 2)
+	})
+
+	t.Run("polls every account", func(t *testing.T) {
+		a := &fakeFetcher{}
+		b := &fakeFetcher{}
+		r := &recorder{}
+
+		w, _ := newTestWatcher(map[string]*fakeFetcher{"a": a, "b": b}, r,
+			Options{Accounts: []string{"a", "b"}})
+
+		require.NoError(t, w.Poll(ctx))
+
+		b.transactions = []inter.Transaction{testTransaction(2, 5, "TED recebida")}
+
+		require.NoError(t, w.Poll(ctx))
+		require.Len(t, r.events, 1)
+		require.Equal(t, "b", r.events[0].Account)
+		require.Len(t, a.windows, 2)
+	})
+
+	t.Run("forgets transactions out of the lookback window", func(t *testing.T) {
+		f := &fakeFetcher{transactions: []inter.Transaction{
+			testTransaction(1, 10, "Pix recebido"),
+		}}
+
+		w, _ := newTestWatcher(map[string]*fakeFetcher{"": f}, &recorder{}, Options{})
+
+		require.NoError(t, w.Poll(ctx))
+		require.Len(t, w.accounts[""].seen, 1)
+
+		w.now = func() time.Time { return testNow.AddDate(0, 0, 2) }
+		f.transactions = nil
+
+		require.NoError(t, w.Poll(ctx))
+		require.Empty(t, w.accounts[""].seen)
+	})
+
+	t.Run("sends errors to the sink", func(t *testing.T) {
+		f := &fakeFetcher{err: errors.New("connection refused")}
+		r := &recorder{}
+
+		w, _ := newTestWatcher(map[string]*fakeFetcher{"": f}, r, Options{})
+
+		require.Error(t, w.Poll(ctx))
+		require.Len(t, r.events, 1)
+		require.Equal(t, notify.ErrorEvent, r.events[0].Type)
+		require.Equal(t, "connection refused", r.events[0].Message)
+	})
+
+	t.Run("connects again when the token is rejected", func(t *testing.T) {
+		f := &fakeFetcher{err: &inter.APIError{StatusCode: http.StatusUnauthorized}}
+		other := &fakeFetcher{}
+
+		w, connects := newTestWatcher(map[string]*fakeFetcher{"a": f, "b": other},
+			&recorder{}, Options{Accounts: []string{"a", "b"}})
+
+		require.Error(t, w.Poll(ctx))
+		require.Equal(t, 1, *connects)
+		require.Empty(t, other.windows)
+
+		f.err = nil
+
+		require.NoError(t, w.Poll(ctx))
+		require.Equal(t, 2, *connects)
+	})
+
+	t.Run("connects again before the session expires", func(t *testing.T) {
+		f := &fakeFetcher{}
+
+		w, connects := newTestWatcher(map[string]*fakeFetcher{"": f}, &recorder{}, Options{})
+
+		require.NoError(t, w.Poll(ctx))
+		require.NoError(t, w.Poll(ctx))
+		require.Equal(t, 1, *connects)
+
+		w.now = func() time.Time { return testNow.Add(time.Hour - time.Second) }
+
+		require.NoError(t, w.Poll(ctx))
+		require.Equal(t, 2, *connects)
+	})
+
+	t.Run("stops polling accounts when rate limited", func(t *testing.T) {
+		f := &fakeFetcher{err: &inter.APIError{
+			StatusCode: http.StatusTooManyRequests,
+			RetryAfter: 30 * time.Second,
+		}}
+		other := &fakeFetcher{}
+
+		w, _ := newTestWatcher(map[string]*fakeFetcher{"a": f, "b": other},
+			&recorder{}, Options{Accounts: []string{"a", "b"}})
+
+		err := w.Poll(ctx)
+
+		var apiErr *inter.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.True(t, apiErr.RateLimited())
+		require.Empty(t, other.windows)
+	})
+
+	t.Run("stops running when the session can not be renewed", func(t *testing.T) {
+		w := New(func(ctx context.Context) (Session, error) {
+			return Session{}, ErrSessionExpired
+		}, &recorder{}, Options{})
+
+		require.ErrorIs(t, w.Run(ctx), ErrSessionExpired)
+	})
+
+	t.Run("stops running when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		f := &fakeFetcher{}
+		w, _ := newTestWatcher(map[string]*fakeFetcher{"": f}, &recorder{},
+			Options{Interval: time.Millisecond})
+
+		require.NoError(t, w.Run(ctx))
+		require.Greater(t, len(f.windows), 1)
+	})
+}